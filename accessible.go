@@ -0,0 +1,131 @@
+package bobarista
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// runAccessible runs the flow as linear plain-text prompts without a tea.Program.
+// Each form is announced with its name and step number, and the summary is printed as plain lines.
+// It returns the flow's last error, or ErrAborted if the flow ended without completing.
+func (f *Bobarista) runAccessible() error {
+	out := f.config.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	defer f.publish()
+	f.infoLog("Running Bobarista in accessible mode")
+	fmt.Fprintln(out, f.config.Title)
+
+	f.awaitLoad(f.Init())
+
+	for f.state == StateActive && f.currentForm != nil {
+		current := f.navigator.Current()
+		fmt.Fprintf(out, "\n%s\n\n", f.renderer.renderAccessibleStep(f))
+
+		if err := f.currentForm.Run(); err != nil {
+			f.errorLog(fmt.Errorf("accessible form error for '%s': %w", current.ID, err))
+			f.addError(current.ID, err)
+			break
+		}
+		f.currentForm.State = huh.StateCompleted
+
+		_, cmd := f.handleFormCompletion()
+		f.awaitLoad(cmd)
+		f.publish()
+	}
+
+	if f.state == StateCompleted {
+		fmt.Fprintf(out, "\n%s\n", f.renderer.renderAccessibleCompleted(f))
+		if f.finish() == nil {
+			return nil
+		}
+	}
+
+	if f.state == StateError {
+		fmt.Fprintf(out, "\n%s\n", f.renderer.renderAccessibleError(f))
+		f.abortFlow()
+	}
+
+	if len(f.errors) > 0 {
+		return f.errors[len(f.errors)-1]
+	}
+	return ErrAborted
+}
+
+// awaitLoad waits for a pending form loader started by cmd and feeds its result back into the flow.
+// Accessible mode has no tea.Program, so the loader and timeout commands are run directly.
+func (f *Bobarista) awaitLoad(cmd tea.Cmd) {
+	if !f.loading || cmd == nil {
+		return
+	}
+
+	results := make(chan tea.Msg, 8)
+	var run func(tea.Cmd)
+	run = func(cmd tea.Cmd) {
+		go func() {
+			msg := cmd()
+			if batch, ok := msg.(tea.BatchMsg); ok {
+				for _, next := range batch {
+					if next != nil {
+						run(next)
+					}
+				}
+				return
+			}
+			results <- msg
+		}()
+	}
+	run(cmd)
+
+	for f.loading {
+		switch msg := (<-results).(type) {
+		case formLoadedMsg:
+			f.handleFormLoaded(msg)
+		case formLoadTimeoutMsg:
+			f.handleFormLoadTimeout(msg)
+		}
+	}
+}
+
+// renderAccessibleStep announces the current form with its step number.
+func (r *Renderer) renderAccessibleStep(cupSleeve *Bobarista) string {
+	current := cupSleeve.navigator.Current()
+	if current == nil {
+		return ""
+	}
+
+	name := current.Name
+	if name == "" {
+		name = current.ID
+	}
+
+	return r.text(MsgStep,
+		cupSleeve.navigator.GetCurrentIndex()+1, cupSleeve.navigator.GetFormCount(), name)
+}
+
+// renderAccessibleCompleted renders the completion summary as plain lines.
+// Styling from a DisplayCallback is stripped.
+func (r *Renderer) renderAccessibleCompleted(cupSleeve *Bobarista) string {
+	var content string
+	if cupSleeve.config.DisplayCallback != nil {
+		content = ansi.Strip(cupSleeve.config.DisplayCallback())
+	} else {
+		content = r.summaryContent(cupSleeve, func(value string) string {
+			return value
+		})
+	}
+
+	return r.text(MsgCompletedTitle, cupSleeve.config.Title) + "\n\n" + strings.TrimRight(content, "\n")
+}
+
+// renderAccessibleError renders the accumulated errors as plain lines.
+func (r *Renderer) renderAccessibleError(cupSleeve *Bobarista) string {
+	return r.text(MsgErrorTitle, cupSleeve.config.Title) + "\n\n" + r.errorContent(cupSleeve)
+}
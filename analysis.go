@@ -0,0 +1,272 @@
+package bobarista
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity indicates how serious a Diagnostic is.
+type Severity int
+
+const (
+	// SeverityWarning marks findings that may be intentional, such as loops.
+	SeverityWarning Severity = iota
+	// SeverityError marks findings that will most likely break the flow.
+	SeverityError
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
+// Diagnostic is a finding of the static analysis of a flow.
+type Diagnostic struct {
+	// Severity indicates how serious the finding is.
+	Severity Severity
+
+	// FormID is the form the finding applies to.
+	FormID string
+
+	// Key is the global key involved, if any.
+	Key string
+
+	// Path lists the form IDs of a navigation cycle, starting and ending with the same form.
+	Path []string
+
+	// Err is ErrUnwrittenRead, ErrUnusedWrite or ErrNavigationCycle.
+	Err error
+}
+
+// Error implements the error interface, describing the finding.
+func (d Diagnostic) Error() string {
+	switch {
+	case len(d.Path) > 0:
+		return fmt.Sprintf("%s: %s", d.Err, strings.Join(d.Path, " → "))
+	case d.Key != "":
+		return fmt.Sprintf("form '%s': %s: '%s'", d.FormID, d.Err, d.Key)
+	default:
+		return fmt.Sprintf("form '%s': %s", d.FormID, d.Err)
+	}
+}
+
+// Unwrap returns the sentinel error of the finding.
+func (d Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// Analyze checks the dependencies declared with WithReads and WithWrites against
+// the navigation graph of the flow. It reports reads of keys that no form able to
+// run earlier writes, writes that no form reads, and cycles in navigation.
+// Keys in provided are available from the start, for example from defaults or OnInit,
+// and keys in consumed count as read outside of forms, for example by the completion summary.
+func (n *Navigator) Analyze(provided, consumed []string) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	graph := n.formGraph()
+	reach := make(map[string]map[string]bool, len(n.forms))
+	for _, form := range n.forms {
+		reach[form.ID] = graph.reachable(form.ID)
+	}
+
+	available := make(map[string]bool, len(provided))
+	for _, key := range provided {
+		available[key] = true
+	}
+	read := make(map[string]bool, len(consumed))
+	for _, key := range consumed {
+		read[key] = true
+	}
+	for _, form := range n.forms {
+		for _, key := range form.declaredReads() {
+			read[key] = true
+		}
+	}
+
+	for _, form := range n.forms {
+		for _, key := range form.declaredReads() {
+			if available[key] || n.writtenBefore(key, form.ID, reach) {
+				continue
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityError,
+				FormID:   form.ID,
+				Key:      key,
+				Err:      ErrUnwrittenRead,
+			})
+		}
+	}
+
+	for _, form := range n.forms {
+		for _, key := range form.Writes {
+			if read[key] {
+				continue
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityWarning,
+				FormID:   form.ID,
+				Key:      key,
+				Err:      ErrUnusedWrite,
+			})
+		}
+	}
+
+	for _, cycle := range graph.cycles(n.formIDs()) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			FormID:   cycle[0],
+			Path:     cycle,
+			Err:      ErrNavigationCycle,
+		})
+	}
+
+	return diagnostics
+}
+
+// writtenBefore reports whether a form that writes key can run before the form with the given ID.
+func (n *Navigator) writtenBefore(key, formID string, reach map[string]map[string]bool) bool {
+	for _, writer := range n.forms {
+		for _, written := range writer.Writes {
+			if written == key && reach[writer.ID][formID] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formGraph maps each form ID to the forms navigation can move to from it.
+type formGraph map[string][]string
+
+// formGraph builds the navigation graph between forms, leaving out the start and end of the flow.
+func (n *Navigator) formGraph() formGraph {
+	graph := make(formGraph, len(n.forms))
+	for _, edge := range n.diagramEdges() {
+		if edge.from == diagramStart || edge.to == diagramEnd {
+			continue
+		}
+		graph[edge.from] = append(graph[edge.from], edge.to)
+	}
+	return graph
+}
+
+// reachable returns the forms that can be reached from the given form in one or more steps.
+// The form itself is included only if it lies on a cycle.
+func (g formGraph) reachable(from string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), g[from]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		queue = append(queue, g[id]...)
+	}
+	return seen
+}
+
+// cycles returns one path for each back edge found by a depth-first search from
+// each form in order. Every path starts and ends with the same form.
+func (g formGraph) cycles(order []string) [][]string {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[string]int, len(order))
+	var stack []string
+	var found [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = active
+		stack = append(stack, id)
+		for _, next := range g[id] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case active:
+				for i, onStack := range stack {
+					if onStack == next {
+						cycle := append([]string(nil), stack[i:]...)
+						found = append(found, append(cycle, next))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	for _, id := range order {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return found
+}
+
+// analyze runs the static analysis when the flow is built.
+// Diagnostics are logged, and with strict analysis errors put the flow in the error state.
+func (f *Bobarista) analyze() {
+	provided := append([]string(nil), f.config.ProvidedKeys...)
+	for key := range f.config.Defaults {
+		provided = append(provided, key)
+	}
+	consumed := append([]string(nil), f.config.DisplayKeys...)
+	for _, derived := range f.config.Derived {
+		provided = append(provided, derived.Key)
+		consumed = append(consumed, derived.Inputs...)
+	}
+
+	f.diagnostics = f.navigator.Analyze(provided, consumed)
+	for _, diagnostic := range f.diagnostics {
+		f.warningLog(fmt.Sprintf("Flow analysis %s: %s", diagnostic.Severity, diagnostic.Error()))
+		if f.config.StrictAnalysis && diagnostic.Severity == SeverityError {
+			f.addError("", diagnostic)
+		}
+	}
+}
+
+// GetDiagnostics returns the findings of the static analysis run when the flow was built.
+func (f *Bobarista) GetDiagnostics() []Diagnostic {
+	diagnostics := make([]Diagnostic, len(f.diagnostics))
+	copy(diagnostics, f.diagnostics)
+	return diagnostics
+}
+
+// recordSkipReason stores why the form was skipped, from the values of the keys it declares as read.
+func (f *Bobarista) recordSkipReason(form *Form) {
+	if f.skipReasons == nil {
+		f.skipReasons = make(map[string]string)
+	}
+
+	parts := make([]string, 0, len(form.Reads))
+	for _, key := range form.declaredReads() {
+		value, exists := f.globalData.Values.Get(key)
+		if !exists {
+			parts = append(parts, key+" unset")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s = %q", key, value))
+	}
+	f.skipReasons[form.ID] = strings.Join(parts, ", ")
+}
+
+// GetSkipReason describes why the form was last skipped, using the values of the keys it reads.
+// The reason is empty if the form declares no reads, and false is returned if the form
+// has not been skipped since it was last entered.
+func (f *Bobarista) GetSkipReason(formID string) (string, bool) {
+	reason, exists := f.skipReasons[formID]
+	return reason, exists
+}
@@ -2,7 +2,12 @@ package bobarista
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"sync"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 )
@@ -19,6 +24,37 @@ type Bobarista struct {
 	state       BobaState
 	errors      []error
 	formValues  map[string]FormValues
+	loading     bool
+	loadSeq     int
+	subscribers []subscription
+	nextSubID   int
+	ownership   *valueOwnership
+	prefilled   bool
+	initial     FormValues
+	recording   bool
+	recordFile  *os.File
+
+	keys           KeyMap
+	confirmingQuit bool
+	inspector      *inspector
+	eventLog       []Event
+	diagnostics    []Diagnostic
+	skipReasons    map[string]string
+	derivedInputs  map[string]string
+
+	snapshotMu sync.RWMutex
+	snapshot   Snapshot
+	program    *tea.Program
+	running    bool
+	finished   bool
+	onExit     tea.Cmd
+	runID      int
+
+	timeoutsArmed bool
+	timedOut      bool
+	timeoutSeq    int
+	flowDeadline  time.Time
+	formDeadline  time.Time
 }
 
 // BobaState represents the current state of the form flow.
@@ -33,18 +69,73 @@ const (
 	StateError
 )
 
+// String returns the name of the state.
+func (s BobaState) String() string {
+	switch s {
+	case StateActive:
+		return "Active"
+	case StateCompleted:
+		return "Completed"
+	case StateError:
+		return "Error"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(s))
+	}
+}
+
 // Run starts the form flow and blocks until completion or error.
 // It initializes the Bubble Tea program and handles the main event loop.
 func (f *Bobarista) Run() error {
 	f.infoLog("Starting Bobarista form flow")
-	_, err := tea.NewProgram(f, tea.WithAltScreen()).Run()
+	if f.config.Accessible {
+		err := f.runAccessible()
+		f.infoLog("Bobarista form flow completed")
+		return err
+	}
+
+	err := f.runProgram(nil)
+	f.infoLog("Bobarista form flow completed")
+	return err
+}
+
+// runProgram runs the flow in a tea.Program built from the configured options.
+// feed, if set, is started in its own goroutine to send messages into the program.
+func (f *Bobarista) runProgram(feed func(program *tea.Program)) error {
+	program := tea.NewProgram(f, programOptions(f.config)...)
+	f.setProgram(program)
+	defer f.stopRecording()
+	if feed != nil {
+		go feed(program)
+	}
+	_, err := program.Run()
+	f.setProgram(nil)
 	if err != nil {
 		f.errorLog(fmt.Errorf("tea program error: %w", err))
 	}
-	f.infoLog("Bobarista form flow completed")
 	return err
 }
 
+// programOptions returns the tea.Program options for the configured input and output.
+func programOptions(config Recipe) []tea.ProgramOption {
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if config.Input != nil {
+		options = append(options, tea.WithInput(config.Input))
+	}
+	if config.Output != nil {
+		options = append(options, tea.WithOutput(config.Output))
+	}
+	return options
+}
+
+// quit returns the command that ends the flow. It quits the program, unless the
+// flow runs inside another model such as a Launcher that needs to take over.
+func (f *Bobarista) quit() tea.Cmd {
+	if f.onExit != nil {
+		return f.onExit
+	}
+	return tea.Quit
+}
+
 // New creates a new BobaBuilder with the specified title.
 // This is the entry point for creating a new form flow.
 func New(title string) *BobaBuilder {
@@ -60,7 +151,10 @@ func New(title string) *BobaBuilder {
 // Init implements the tea.Model interface and initializes the form flow.
 // It sets up global data, form values, and navigates to the first valid form.
 func (f *Bobarista) Init() tea.Cmd {
+	defer f.publish()
 	f.infoLog("Initializing Bobarista")
+	f.startRecording()
+	f.emit(Event{Type: EventFlowStarted})
 
 	if f.globalData == nil {
 		f.debugLog("Initializing global data")
@@ -75,6 +169,13 @@ func (f *Bobarista) Init() tea.Cmd {
 		f.formValues = make(map[string]FormValues)
 	}
 
+	if err := f.applyPrefill(); err != nil {
+		f.errorLog(err)
+		f.addError("", err)
+		return nil
+	}
+	f.ownership.seed(*f.globalData.Values)
+
 	if f.config.OnInit != nil {
 		f.debugLog("Calling OnInit callback")
 		formDataList := make([]FormData, len(f.forms))
@@ -86,6 +187,15 @@ func (f *Bobarista) Init() tea.Cmd {
 		}
 		f.config.OnInit(f, formDataList)
 	}
+	f.initial = f.globalData.Values.Copy()
+
+	return f.start()
+}
+
+// start derives values and enters the first valid form.
+// It is shared by Init and a reset, which must not emit FlowStarted or run OnInit again.
+func (f *Bobarista) start() tea.Cmd {
+	f.updateDerived()
 
 	f.debugLog("Moving to first valid form")
 	if err := f.navigator.MoveToFirstValid(*f.globalData); err != nil {
@@ -93,6 +203,9 @@ func (f *Bobarista) Init() tea.Cmd {
 		f.addError("", err)
 		return nil
 	}
+	if !f.notifySkipped() {
+		return nil
+	}
 
 	return f.initCurrentForm()
 }
@@ -100,6 +213,24 @@ func (f *Bobarista) Init() tea.Cmd {
 // Update implements the tea.Model interface and handles incoming messages.
 // It processes keyboard input, window resize events, and form state changes.
 func (f *Bobarista) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer f.publish()
+	f.recordMsg(msg)
+
+	if _, ok := msg.(tea.KeyMsg); ok {
+		if f.timeoutExpired() {
+			return f, f.handleTimeoutTick(timeoutTickMsg{run: f.runID, seq: f.timeoutSeq})
+		}
+		f.restartFormTimeout()
+		if arm := f.armTimeouts(); arm != nil {
+			model, cmd := f.update(msg)
+			return model, tea.Batch(cmd, arm)
+		}
+	}
+	return f.update(msg)
+}
+
+// update dispatches a message to the handler for the current state.
+func (f *Bobarista) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		f.renderer.UpdateSize(msg.Width, msg.Height)
@@ -110,18 +241,41 @@ func (f *Bobarista) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return f, cmd
 		}
+	case formLoadedMsg:
+		return f, f.handleFormLoaded(msg)
+	case formLoadTimeoutMsg:
+		return f, f.handleFormLoadTimeout(msg)
+	case ValueUpdateMsg:
+		f.applyValueUpdate(msg)
+		return f, nil
+	case gotoMsg, abortMsg, completeMsg:
+		return f, f.handleControl(msg)
+	case timeoutTickMsg:
+		return f, f.handleTimeoutTick(msg)
 	case tea.KeyMsg:
 		if f.state == StateCompleted || f.state == StateError {
 			return f.handleCompletedState(msg)
 		}
 
-		switch msg.String() {
-		case "ctrl+c":
-			f.infoLog("User pressed Ctrl+C, quitting")
-			return f, tea.Quit
-		case "esc":
-			f.infoLog("User pressed Esc, quitting")
-			return f, tea.Quit
+		if f.confirmingQuit {
+			return f.handleQuitConfirmation(msg)
+		}
+
+		if f.inspector != nil && f.state == StateActive {
+			if cmd, handled := f.handleInspectorKey(msg); handled {
+				return f, cmd
+			}
+		}
+
+		if key.Matches(msg, f.keys.Quit) {
+			if f.config.ConfirmQuit {
+				f.infoLog(fmt.Sprintf("User pressed %s, asking for quit confirmation", msg.String()))
+				f.confirmingQuit = true
+				return f, nil
+			}
+			f.infoLog(fmt.Sprintf("User pressed %s, quitting", msg.String()))
+			f.abortFlow()
+			return f, f.quit()
 		}
 
 		if f.state == StateActive && f.currentForm != nil {
@@ -145,31 +299,71 @@ func (f *Bobarista) View() string {
 // handleCompletedState processes input when the form flow is in completed or error state.
 // It handles scrolling, quitting, and completion callbacks.
 func (f *Bobarista) handleCompletedState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "up":
+	switch {
+	case key.Matches(msg, f.keys.ScrollUp):
 		f.renderer.HandleScroll(-1)
 		return f, nil
-	case "down":
+	case key.Matches(msg, f.keys.ScrollDown):
 		f.renderer.HandleScroll(1)
 		return f, nil
-	case "q", "esc":
+	case key.Matches(msg, f.keys.Close):
 		f.infoLog("User quit from completed/error state")
-		return f, tea.Quit
-	case "enter":
-		if f.state == StateCompleted && f.config.OnComplete != nil {
-			f.debugLog("Calling OnComplete callback")
-			if err := f.config.OnComplete(f); err != nil {
-				f.errorLog(fmt.Errorf("OnComplete callback error: %w", err))
-				f.addError("", err)
-				return f, nil
-			}
+		if f.state == StateError {
+			f.abortFlow()
+		}
+		return f, f.quit()
+	case key.Matches(msg, f.keys.Finish) && f.state == StateCompleted:
+		if err := f.finish(); err != nil {
+			return f, nil
 		}
 		f.infoLog("User finished from completed state")
-		return f, tea.Quit
+		return f, f.quit()
+	}
+	return f, nil
+}
+
+// handleQuitConfirmation processes input while the quit confirmation prompt is shown.
+// Other keys are ignored until the prompt is answered.
+func (f *Bobarista) handleQuitConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, f.keys.ConfirmQuit), key.Matches(msg, f.keys.Quit) && !key.Matches(msg, f.keys.CancelQuit):
+		f.infoLog("User confirmed quit")
+		f.confirmingQuit = false
+		f.abortFlow()
+		return f, f.quit()
+	case key.Matches(msg, f.keys.CancelQuit):
+		f.debugLog("User cancelled quit")
+		f.confirmingQuit = false
 	}
 	return f, nil
 }
 
+// finish runs the OnComplete callback and saves the answers of a completed flow.
+// Errors are added to the flow and returned so the caller can keep the error screen up.
+func (f *Bobarista) finish() error {
+	if f.state != StateCompleted {
+		return nil
+	}
+	if f.config.OnComplete != nil {
+		f.debugLog("Calling OnComplete callback")
+		if err := f.config.OnComplete(f); err != nil {
+			f.errorLog(fmt.Errorf("OnComplete callback error: %w", err))
+			f.addError("", err)
+			return err
+		}
+	}
+	if f.config.PreviousAnswersFile != "" {
+		f.debugLog(fmt.Sprintf("Saving answers to %s", f.config.PreviousAnswersFile))
+		if err := SaveVersionedAnswers(f.config.PreviousAnswersFile, f.config.Version, f.savedAnswers()); err != nil {
+			f.errorLog(fmt.Errorf("failed to save answers: %w", err))
+			f.addError("", err)
+			return err
+		}
+	}
+	f.finished = true
+	return nil
+}
+
 // updateCurrentForm processes messages for the currently active form.
 // It handles form completion and transitions to the next form.
 func (f *Bobarista) updateCurrentForm(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -200,7 +394,7 @@ func (f *Bobarista) handleFormCompletion() (tea.Model, tea.Cmd) {
 	current := f.navigator.Current()
 	if current == nil {
 		f.warningLog("handleFormCompletion called with no current form")
-		f.state = StateCompleted
+		f.completeFlow()
 		return f, nil
 	}
 
@@ -240,6 +434,9 @@ func (f *Bobarista) handleFormCompletion() (tea.Model, tea.Cmd) {
 		Values: currentValues,
 	}
 
+	globalBefore := f.globalData.Values.Copy()
+	globalPointers := maps.Clone(*f.globalData.Values)
+
 	if current.OnComplete != nil {
 		f.debugLog(fmt.Sprintf("Calling OnComplete for form '%s'", current.ID))
 		if err := current.OnComplete(&currentData, f.globalData); err != nil {
@@ -284,6 +481,20 @@ func (f *Bobarista) handleFormCompletion() (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if f.ownership == nil {
+		f.ownership = newValueOwnership()
+	}
+	f.ownership.record(current.ID, writtenKeys(globalPointers, *f.globalData.Values), globalBefore)
+	f.updateDerived()
+
+	f.emit(Event{Type: EventFormCompleted, FormID: current.ID})
+
+	if err := f.runLeaveHooks(current, &currentData); err != nil {
+		f.errorLog(fmt.Errorf("OnLeave error for form '%s': %w", current.ID, err))
+		f.addError(current.ID, err)
+		return f, nil
+	}
+
 	f.debugLog(fmt.Sprintf("Determining next form after '%s'", current.ID))
 	nextIndex, err := f.navigator.Next(*f.globalData)
 	if err != nil {
@@ -291,17 +502,21 @@ func (f *Bobarista) handleFormCompletion() (tea.Model, tea.Cmd) {
 		f.addError(current.ID, err)
 		return f, nil
 	}
+	if !f.notifySkipped() {
+		return f, nil
+	}
+	f.emitNavigation(current.ID, nextIndex)
 
 	f.infoLog(fmt.Sprintf("Next form index: %d", nextIndex))
 
 	switch nextIndex {
 	case -2:
 		f.infoLog("Flow completed (nextIndex = -2)")
-		f.state = StateCompleted
+		f.completeFlow()
 		return f, nil
 	case -1:
 		f.infoLog("No more forms (nextIndex = -1)")
-		f.state = StateCompleted
+		f.completeFlow()
 		return f, nil
 	default:
 		f.infoLog(fmt.Sprintf("Moving to form at index %d", nextIndex))
@@ -320,7 +535,7 @@ func (f *Bobarista) initCurrentForm() tea.Cmd {
 	current := f.navigator.Current()
 	if current == nil {
 		f.warningLog("initCurrentForm called with no current form")
-		f.state = StateCompleted
+		f.completeFlow()
 		return nil
 	}
 
@@ -360,18 +575,28 @@ func (f *Bobarista) initCurrentForm() tea.Cmd {
 		if shouldSkip {
 			f.infoLog(fmt.Sprintf("Skipping form '%s'", current.ID))
 
+			if err := f.runSkipHooks(current); err != nil {
+				f.errorLog(fmt.Errorf("OnSkip error for form '%s': %w", current.ID, err))
+				f.addError(current.ID, err)
+				return nil
+			}
+
 			nextIndex, err := f.navigator.Next(*f.globalData)
 			if err != nil {
 				f.errorLog(fmt.Errorf("navigation error while skipping form '%s': %w", current.ID, err))
 				f.addError(current.ID, err)
 				return nil
 			}
+			if !f.notifySkipped() {
+				return nil
+			}
+			f.emitNavigation(current.ID, nextIndex)
 
 			f.infoLog(fmt.Sprintf("Next form index after skipping '%s': %d", current.ID, nextIndex))
 
 			if nextIndex == -1 || nextIndex == -2 {
 				f.infoLog("No more forms after skip, completing flow")
-				f.state = StateCompleted
+				f.completeFlow()
 				return nil
 			}
 
@@ -387,14 +612,103 @@ func (f *Bobarista) initCurrentForm() tea.Cmd {
 		f.debugLog(fmt.Sprintf("No skip condition defined for form '%s'", current.ID))
 	}
 
-	if current.Generator == nil {
-		f.errorLog(fmt.Errorf("no generator for form '%s'", current.ID))
-		f.addError(current.ID, NewCupSleeveError(current.ID, ErrNoGenerator))
+	return f.enterForm(current)
+}
+
+// enterForm runs the enter hooks of the given form and displays it,
+// starting its loader first if it has one.
+func (f *Bobarista) enterForm(current *Form) tea.Cmd {
+	delete(f.skipReasons, current.ID)
+	f.startFormTimeout(current)
+	if _, exists := f.formValues[current.ID]; !exists {
+		f.formValues[current.ID] = *NewFormValues()
+	}
+	currentValues := f.formValues[current.ID]
+
+	currentData := FormData{
+		ID:     current.ID,
+		Values: &currentValues,
+	}
+
+	if err := f.runEnterHooks(current, &currentData); err != nil {
+		f.errorLog(fmt.Errorf("OnEnter error for form '%s': %w", current.ID, err))
+		f.addError(current.ID, err)
 		return nil
 	}
 
+	f.emit(Event{Type: EventFormEntered, FormID: current.ID})
+
+	if current.Loader != nil {
+		return f.startLoader(current, &currentValues)
+	}
+
+	return f.generateForm(current, &currentValues, nil)
+}
+
+// jumpTo moves the flow directly to the form with the given ID, bypassing its skip condition.
+// The target and the forms after it are marked as not completed, so their values are
+// retracted if the flow completes without passing through them again.
+func (f *Bobarista) jumpTo(formID string) (tea.Cmd, error) {
+	target, index, err := f.navigator.GetFormByID(formID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromID := ""
+	if current := f.navigator.Current(); current != nil {
+		fromID = current.ID
+	}
+
+	if err := f.navigator.MoveTo(index); err != nil {
+		return nil, err
+	}
+	if f.ownership != nil {
+		for _, form := range f.forms[index:] {
+			f.ownership.uncomplete(form.ID)
+		}
+	}
+
+	f.infoLog(fmt.Sprintf("Jumping from form '%s' to form '%s'", fromID, formID))
+	f.state = StateActive
+	f.loading = false
+	f.currentForm = nil
+	f.emitNavigation(fromID, index)
+
+	return f.enterForm(target), nil
+}
+
+// evaluateSkipConditions runs the skip condition of every form against the current data.
+// Forms without a skip condition are reported as not skipped.
+func (f *Bobarista) evaluateSkipConditions() map[string]bool {
+	results := make(map[string]bool, len(f.forms))
+	for _, form := range f.forms {
+		if form.ShouldSkip == nil {
+			results[form.ID] = false
+			continue
+		}
+		values, exists := f.formValues[form.ID]
+		if !exists {
+			values = *NewFormValues()
+		}
+		results[form.ID] = form.ShouldSkip(&FormData{ID: form.ID, Values: &values}, f.globalData)
+	}
+	return results
+}
+
+// generateForm creates the huh.Form for the given form definition and initializes it.
+// Loaded data is passed to the LoadedGenerator when one is set.
+func (f *Bobarista) generateForm(current *Form, currentValues *FormValues, data any) tea.Cmd {
 	f.debugLog(fmt.Sprintf("Generating form '%s'", current.ID))
-	f.currentForm = current.Generator(&currentValues, f.globalData.Values)
+	switch {
+	case current.LoadedGenerator != nil:
+		f.currentForm = current.LoadedGenerator(currentValues, f.globalData.Values, data)
+	case current.Generator != nil:
+		f.currentForm = current.Generator(currentValues, f.globalData.Values)
+	default:
+		f.errorLog(fmt.Errorf("no generator for form '%s'", current.ID))
+		f.addError(current.ID, NewCupSleeveError(current.ID, ErrNoGenerator))
+		return nil
+	}
 
 	if f.currentForm == nil {
 		f.errorLog(fmt.Errorf("generator returned nil form for '%s'", current.ID))
@@ -402,6 +716,19 @@ func (f *Bobarista) initCurrentForm() tea.Cmd {
 		return nil
 	}
 
+	switch {
+	case f.config.Accessible:
+		f.currentForm = f.currentForm.WithTheme(huh.ThemeBase()).WithAccessible(true)
+		if f.config.Input != nil {
+			f.currentForm = f.currentForm.WithInput(f.config.Input)
+		}
+		if f.config.Output != nil {
+			f.currentForm = f.currentForm.WithOutput(f.config.Output)
+		}
+	case !f.config.DisableFormTheme:
+		f.currentForm = f.currentForm.WithTheme(f.renderer.FormTheme())
+	}
+
 	f.infoLog(fmt.Sprintf("Form '%s' initialized successfully", current.ID))
 	return f.currentForm.Init()
 }
@@ -416,10 +743,12 @@ func (f *Bobarista) addError(formID string, err error) {
 		f.errors = append(f.errors, NewCupSleeveError(formID, err))
 	}
 	f.state = StateError
+	f.emit(Event{Type: EventErrorRaised, FormID: formID, Err: f.errors[len(f.errors)-1]})
 }
 
 // GetGlobalData returns a copy of the global form data.
 // This contains values that are shared across all forms in the flow.
+// The values are shared with the running flow; use GetSnapshot from other goroutines.
 func (f *Bobarista) GetGlobalData() FormData {
 	if f.globalData == nil {
 		return FormData{ID: "global", Values: NewFormValues()}
@@ -448,6 +777,11 @@ func (f *Bobarista) GetCurrentFormData() FormData {
 	}
 }
 
+// GetState returns the current state of the form flow.
+func (f *Bobarista) GetState() BobaState {
+	return f.state
+}
+
 // GetErrors returns all errors that have occurred during the form flow.
 // This is useful for debugging and error handling.
 func (f *Bobarista) GetErrors() []error {
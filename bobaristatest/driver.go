@@ -0,0 +1,360 @@
+// Package bobaristatest provides helpers for testing Bobarista form flows.
+// It drives a flow programmatically without a terminal, so tests can send keys,
+// inspect the flow's state and values, and capture what the renderer produces.
+package bobaristatest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/choice404/bobarista"
+)
+
+// DefaultSettleTimeout is how long the driver waits for a command to produce a message.
+// Commands that take longer, such as cursor blink ticks, are dropped.
+const DefaultSettleTimeout = 50 * time.Millisecond
+
+// Driver runs a Bobarista flow without a terminal.
+// Every message sent through the driver is processed synchronously, including the
+// messages produced by the commands it returns, and the rendered view is captured.
+type Driver struct {
+	t       testing.TB
+	boba    *bobarista.Bobarista
+	width   int
+	height  int
+	settle  time.Duration
+	frames  []string
+	started bool
+	quit    bool
+}
+
+// Option configures a Driver.
+type Option func(*Driver)
+
+// WithSize sets the terminal size reported to the flow.
+func WithSize(width, height int) Option {
+	return func(d *Driver) {
+		d.width = width
+		d.height = height
+	}
+}
+
+// WithSettleTimeout sets how long the driver waits for each command to produce a message.
+// Increase it for flows whose loaders take longer than DefaultSettleTimeout.
+func WithSettleTimeout(timeout time.Duration) Option {
+	return func(d *Driver) {
+		d.settle = timeout
+	}
+}
+
+// NewDriver creates a Driver for the given flow.
+// The flow is not initialized until Start is called.
+func NewDriver(t testing.TB, boba *bobarista.Bobarista, opts ...Option) *Driver {
+	d := &Driver{
+		t:      t,
+		boba:   boba,
+		width:  80,
+		height: 24,
+		settle: DefaultSettleTimeout,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Start initializes the flow and reports the terminal size to it.
+// It is called automatically by the first Send if needed.
+func (d *Driver) Start() *Driver {
+	if d.started {
+		return d
+	}
+	d.started = true
+	d.process(d.run(d.boba.Init()))
+	d.Send(tea.WindowSizeMsg{Width: d.width, Height: d.height})
+	return d
+}
+
+// Send delivers a message to the flow and processes all resulting commands.
+// The rendered view is captured as a new frame afterwards.
+func (d *Driver) Send(msg tea.Msg) *Driver {
+	if !d.started {
+		d.Start()
+	}
+	if d.quit {
+		d.t.Logf("bobaristatest: flow has quit, ignoring %T", msg)
+		return d
+	}
+	d.process([]tea.Msg{msg})
+	d.frames = append(d.frames, d.boba.View())
+	return d
+}
+
+// Press sends one key press per argument.
+// Keys use Bubble Tea's names, such as "enter", "tab", "down", "esc", or "ctrl+c".
+// Any other string is sent as typed runes.
+func (d *Driver) Press(keys ...string) *Driver {
+	for _, k := range keys {
+		d.Send(KeyMsg(k))
+	}
+	return d
+}
+
+// Type sends each rune of text as a separate key press.
+func (d *Driver) Type(text string) *Driver {
+	for _, r := range text {
+		d.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return d
+}
+
+// Submit presses enter.
+func (d *Driver) Submit() *Driver {
+	return d.Press("enter")
+}
+
+// Resize reports a new terminal size to the flow.
+func (d *Driver) Resize(width, height int) *Driver {
+	d.width = width
+	d.height = height
+	return d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Flow returns the flow being driven.
+func (d *Driver) Flow() *bobarista.Bobarista {
+	return d.boba
+}
+
+// View returns the current rendered view.
+func (d *Driver) View() string {
+	return d.boba.View()
+}
+
+// Frames returns the view captured after each message sent through the driver.
+func (d *Driver) Frames() []string {
+	return d.frames
+}
+
+// Quit returns true once the flow has requested the program to quit.
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+// CurrentFormID returns the ID of the active form, or an empty string if none is active.
+func (d *Driver) CurrentFormID() string {
+	return d.boba.GetCurrentFormData().ID
+}
+
+// State returns the flow's current state.
+func (d *Driver) State() bobarista.BobaState {
+	return d.boba.GetState()
+}
+
+// AssertFormID fails the test if the active form is not id.
+func (d *Driver) AssertFormID(id string) {
+	d.t.Helper()
+	if got := d.CurrentFormID(); got != id {
+		d.t.Errorf("current form = %q, want %q", got, id)
+	}
+}
+
+// AssertState fails the test if the flow is not in the given state.
+func (d *Driver) AssertState(state bobarista.BobaState) {
+	d.t.Helper()
+	if got := d.State(); got != state {
+		d.t.Errorf("state = %v, want %v", got, state)
+	}
+}
+
+// AssertValue fails the test if the global value for key is not want.
+func (d *Driver) AssertValue(key, want string) {
+	d.t.Helper()
+	got, exists := d.boba.GetGlobalData().Values.Get(key)
+	if !exists {
+		d.t.Errorf("global value %q is not set, want %q", key, want)
+		return
+	}
+	if got != want {
+		d.t.Errorf("global value %q = %q, want %q", key, got, want)
+	}
+}
+
+// AssertNoValue fails the test if the global data contains key.
+func (d *Driver) AssertNoValue(key string) {
+	d.t.Helper()
+	if got, exists := d.boba.GetGlobalData().Values.Get(key); exists {
+		d.t.Errorf("global value %q = %q, want unset", key, got)
+	}
+}
+
+// AssertViewContains fails the test if the current view does not contain text.
+func (d *Driver) AssertViewContains(text string) {
+	d.t.Helper()
+	if view := d.View(); !strings.Contains(view, text) {
+		d.t.Errorf("view does not contain %q:\n%s", text, view)
+	}
+}
+
+// process delivers messages to the flow until no more are produced.
+func (d *Driver) process(queue []tea.Msg) {
+	for len(queue) > 0 && !d.quit {
+		msg := queue[0]
+		queue = queue[1:]
+
+		_, cmd := d.boba.Update(msg)
+		queue = append(queue, d.run(cmd)...)
+	}
+}
+
+// run executes a command and returns the messages it produces.
+// Batched commands run concurrently and sequenced commands run in order.
+func (d *Driver) run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	msg, ok := d.exec(cmd)
+	if !ok {
+		return nil
+	}
+	return d.expand(msg)
+}
+
+// expand unpacks batch and sequence messages into the messages of their commands.
+func (d *Driver) expand(msg tea.Msg) []tea.Msg {
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tea.QuitMsg:
+		d.quit = true
+		return nil
+	case tea.BatchMsg:
+		return d.runBatch(msg)
+	}
+
+	if cmds, ok := sequenceCmds(msg); ok {
+		var msgs []tea.Msg
+		for _, c := range cmds {
+			msgs = append(msgs, d.run(c)...)
+		}
+		return msgs
+	}
+
+	if isWindowSizeRequest(msg) {
+		return []tea.Msg{tea.WindowSizeMsg{Width: d.width, Height: d.height}}
+	}
+
+	return []tea.Msg{msg}
+}
+
+// runBatch executes commands concurrently and returns their messages in command order.
+func (d *Driver) runBatch(cmds []tea.Cmd) []tea.Msg {
+	results := make([]chan tea.Msg, len(cmds))
+	for i, c := range cmds {
+		if c == nil {
+			continue
+		}
+		results[i] = make(chan tea.Msg, 1)
+		go func(c tea.Cmd, out chan<- tea.Msg) {
+			out <- c()
+		}(c, results[i])
+	}
+
+	deadline := time.After(d.settle)
+	var msgs []tea.Msg
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		select {
+		case msg := <-result:
+			msgs = append(msgs, d.expand(msg)...)
+		case <-deadline:
+			return msgs
+		}
+	}
+	return msgs
+}
+
+// exec runs a single command, giving up after the settle timeout.
+func (d *Driver) exec(cmd tea.Cmd) (tea.Msg, bool) {
+	result := make(chan tea.Msg, 1)
+	go func() {
+		result <- cmd()
+	}()
+
+	select {
+	case msg := <-result:
+		return msg, true
+	case <-time.After(d.settle):
+		return nil, false
+	}
+}
+
+// cmdType is the reflected type of tea.Cmd.
+var cmdType = reflect.TypeOf((*tea.Cmd)(nil)).Elem()
+
+// sequenceCmds extracts the commands of a tea.Sequence message.
+// The message type is unexported by Bubble Tea, so it is recognised by its shape.
+func sequenceCmds(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != cmdType {
+		return nil, false
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i] = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds, true
+}
+
+// isWindowSizeRequest reports whether msg is the message produced by tea.WindowSize.
+func isWindowSizeRequest(msg tea.Msg) bool {
+	return fmt.Sprintf("%T", msg) == "tea.windowSizeMsg"
+}
+
+// keyTypes maps key names to their Bubble Tea key types.
+var keyTypes = map[string]tea.KeyType{
+	"enter":     tea.KeyEnter,
+	"tab":       tea.KeyTab,
+	"shift+tab": tea.KeyShiftTab,
+	"esc":       tea.KeyEsc,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"space":     tea.KeySpace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"ctrl+a":    tea.KeyCtrlA,
+	"ctrl+c":    tea.KeyCtrlC,
+	"ctrl+d":    tea.KeyCtrlD,
+	"ctrl+e":    tea.KeyCtrlE,
+	"ctrl+k":    tea.KeyCtrlK,
+	"ctrl+u":    tea.KeyCtrlU,
+}
+
+// KeyMsg converts a key name into a tea.KeyMsg.
+// Unknown names are treated as typed runes.
+func KeyMsg(name string) tea.KeyMsg {
+	if keyType, exists := keyTypes[name]; exists {
+		if keyType == tea.KeySpace {
+			return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		}
+		return tea.KeyMsg{Type: keyType}
+	}
+	if alt, found := strings.CutPrefix(name, "alt+"); found {
+		msg := KeyMsg(alt)
+		msg.Alt = true
+		return msg
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
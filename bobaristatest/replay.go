@@ -0,0 +1,44 @@
+package bobaristatest
+
+import "github.com/choice404/bobarista"
+
+// Replay feeds the messages from a session recording into the flow.
+// The test fails if the flow's navigation decisions diverge from the recorded ones.
+func (d *Driver) Replay(path string) *Driver {
+	d.t.Helper()
+
+	if err := d.boba.ValidateReplay(path); err != nil {
+		d.t.Fatalf("cannot replay recording: %v", err)
+	}
+
+	recording, err := bobarista.LoadRecording(path)
+	if err != nil {
+		d.t.Fatalf("failed to load recording: %v", err)
+	}
+
+	var decisions []bobarista.Event
+	unsubscribe := d.boba.Subscribe(func(e bobarista.Event) {
+		if e.Type == bobarista.EventNavigationDecided {
+			decisions = append(decisions, e)
+		}
+	})
+	defer unsubscribe()
+
+	d.Start()
+	for _, msg := range recording.Messages() {
+		d.Send(msg)
+	}
+
+	recorded := recording.Navigation()
+	if len(decisions) != len(recorded) {
+		d.t.Errorf("replay made %d navigation decisions, recording has %d", len(decisions), len(recorded))
+	}
+	for i := 0; i < len(decisions) && i < len(recorded); i++ {
+		got, want := decisions[i], recorded[i]
+		if got.FormID != want.FormID || got.NextFormID != want.NextFormID {
+			d.t.Errorf("navigation %d: replay went %q -> %q, recording went %q -> %q",
+				i, got.FormID, got.NextFormID, want.FormID, want.NextFormID)
+		}
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+package bobaristatest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// updateSnapshots rewrites golden files instead of comparing against them.
+var updateSnapshots = flag.Bool("update-snapshots", false, "update bobaristatest golden files")
+
+// SnapshotDir is the directory, relative to the test's working directory, where golden files are stored.
+var SnapshotDir = "testdata"
+
+// Normalize strips ANSI escape sequences and trailing whitespace from rendered output.
+// This makes snapshots independent of the terminal's color profile.
+func Normalize(view string) string {
+	lines := strings.Split(ansi.Strip(view), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// AssertSnapshot compares the normalized view against the golden file for name.
+// Run the tests with -update-snapshots to create or update golden files.
+func AssertSnapshot(t testing.TB, name string, view string) {
+	t.Helper()
+
+	got := Normalize(view)
+	path := filepath.Join(SnapshotDir, name+".golden")
+
+	if *updateSnapshots {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create snapshot directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("failed to write snapshot %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read snapshot %s (run with -update-snapshots to create it): %v", path, err)
+	}
+
+	if string(want) != got {
+		t.Errorf("snapshot %s does not match:\n%s", path, diffLines(string(want), got))
+	}
+}
+
+// AssertSnapshot compares the current view against the golden file for name.
+// The terminal size is appended to the name, so the same screen can be checked at several sizes.
+// The view must also fit the terminal height, or its top would scroll out of sight.
+func (d *Driver) AssertSnapshot(name string) {
+	d.t.Helper()
+	view := d.View()
+	if lines := strings.Count(view, "\n") + 1; lines > d.height {
+		d.t.Errorf("snapshot %s is %d lines tall, terminal has %d", name, lines, d.height)
+	}
+	AssertSnapshot(d.t, fmt.Sprintf("%s_%dx%d", name, d.width, d.height), view)
+}
+
+// diffLines returns a line-by-line comparison of want and got, marking lines that differ.
+func diffLines(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var out strings.Builder
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w == g {
+			out.WriteString(fmt.Sprintf("  %s\n", w))
+			continue
+		}
+		out.WriteString(fmt.Sprintf("- %s\n+ %s\n", w, g))
+	}
+	return out.String()
+}
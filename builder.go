@@ -2,6 +2,11 @@
 // It allows you to create multi-step forms with navigation, validation, and custom styling.
 package bobarista
 
+import (
+	"io"
+	"time"
+)
+
 // BobaBuilder provides a fluent interface for constructing Bobarista form flows.
 // It allows you to configure forms, styling, and behavior before building the final Bobarista instance.
 type BobaBuilder struct {
@@ -23,13 +28,92 @@ func (b *BobaBuilder) WithMaxWidth(width int) *BobaBuilder {
 	return b
 }
 
+// WithBreakpoints sets the terminal sizes at which the layout adapts.
+// Zero fields keep their default values.
+func (b *BobaBuilder) WithBreakpoints(breakpoints Breakpoints) *BobaBuilder {
+	b.config.Breakpoints = breakpoints
+	return b
+}
+
 // WithColorScheme sets the color scheme for the form display.
 // Available schemes include "default", "dark", "ubuntu", "ocean", "forest", "sunset", and "monochrome".
+// Use "auto" to choose a scheme from the terminal background and NO_COLOR.
 func (b *BobaBuilder) WithColorScheme(scheme string) *BobaBuilder {
 	b.config.ColorScheme = scheme
 	return b
 }
 
+// WithKeyMap replaces the default key bindings.
+// The footer help is generated from the bindings, so it always matches them.
+func (b *BobaBuilder) WithKeyMap(keys KeyMap) *BobaBuilder {
+	b.config.KeyMap = &keys
+	return b
+}
+
+// WithLogger sets the logger that receives the flow's log messages, also outside of debug mode.
+func (b *BobaBuilder) WithLogger(logger Logger) *BobaBuilder {
+	b.config.Logger = logger
+	return b
+}
+
+// WithQuitConfirmation enables or disables asking "are you sure?" before quitting an active flow.
+func (b *BobaBuilder) WithQuitConfirmation(enabled bool) *BobaBuilder {
+	b.config.ConfirmQuit = enabled
+	return b
+}
+
+// WithLocale sets the locale of framework-generated strings.
+// Built-in locales are "en", "de" and "ja"; use "auto" to pick one from LC_ALL, LC_MESSAGES or LANG.
+func (b *BobaBuilder) WithLocale(locale string) *BobaBuilder {
+	b.config.Locale = locale
+	return b
+}
+
+// WithMessage overrides a single entry of the locale's message catalog.
+// Use KeyLabel to set the label shown for a value key in the summary.
+func (b *BobaBuilder) WithMessage(key MessageKey, text string) *BobaBuilder {
+	if b.config.Messages == nil {
+		b.config.Messages = make(Messages)
+	}
+	b.config.Messages[key] = text
+	return b
+}
+
+// WithStyles replaces the styles generated from the color scheme.
+// Start from NewStyles or DefaultStyles to keep the theme's colors.
+func (b *BobaBuilder) WithStyles(styles *Styles) *BobaBuilder {
+	b.config.Styles = styles
+	return b
+}
+
+// WithStyleOverride customizes individual style elements, such as the header filler,
+// border type, alignment, or spacing. Overrides run in the order they are added,
+// after the color scheme has been applied.
+func (b *BobaBuilder) WithStyleOverride(override StyleOverride) *BobaBuilder {
+	b.config.StyleOverrides = append(b.config.StyleOverrides, override)
+	return b
+}
+
+// WithoutFormTheme stops Bobarista from applying the color scheme to generated forms.
+// Use this when generators set their own huh theme.
+func (b *BobaBuilder) WithoutFormTheme() *BobaBuilder {
+	b.config.DisableFormTheme = true
+	return b
+}
+
+// WithThemesDir registers the theme files in dir when the flow is built,
+// so they can be selected with WithColorScheme by file name.
+// If dir is empty, DefaultThemesDir is used.
+func (b *BobaBuilder) WithThemesDir(dir string) *BobaBuilder {
+	if dir == "" {
+		if defaultDir, err := DefaultThemesDir(); err == nil {
+			dir = defaultDir
+		}
+	}
+	b.config.ThemesDir = dir
+	return b
+}
+
 // WithDisplayKeys sets which keys should be displayed in the completion summary.
 // If not set, all non-empty values will be displayed.
 func (b *BobaBuilder) WithDisplayKeys(keys []string) *BobaBuilder {
@@ -37,6 +121,13 @@ func (b *BobaBuilder) WithDisplayKeys(keys []string) *BobaBuilder {
 	return b
 }
 
+// WithDerived defines a global value computed from the given input keys.
+// It is recomputed whenever an input changes, so it appears in the summary and saved answers.
+func (b *BobaBuilder) WithDerived(key string, inputs []string, compute DeriveFunc) *BobaBuilder {
+	b.config.Derived = append(b.config.Derived, DerivedValue{Key: key, Inputs: inputs, Compute: compute})
+	return b
+}
+
 // OnInit sets a callback function that is called when the form flow initializes.
 // The callback receives the Bobarista instance and initial form data for all forms.
 func (b *BobaBuilder) OnInit(handler func(*Bobarista, []FormData)) *BobaBuilder {
@@ -51,6 +142,25 @@ func (b *BobaBuilder) OnComplete(handler func(*Bobarista) error) *BobaBuilder {
 	return b
 }
 
+// OnFormEnter sets a callback that is called whenever a form in the flow is entered.
+// This is useful for flow-wide concerns such as analytics.
+func (b *BobaBuilder) OnFormEnter(hook FlowHook) *BobaBuilder {
+	b.config.OnFormEnter = hook
+	return b
+}
+
+// OnFormLeave sets a callback that is called whenever a form in the flow is left after completion.
+func (b *BobaBuilder) OnFormLeave(hook FlowHook) *BobaBuilder {
+	b.config.OnFormLeave = hook
+	return b
+}
+
+// OnFormSkip sets a callback that is called whenever a form in the flow is skipped.
+func (b *BobaBuilder) OnFormSkip(hook FlowHook) *BobaBuilder {
+	b.config.OnFormSkip = hook
+	return b
+}
+
 // WithDisplayCallback sets a custom function to generate the completion display content.
 // If not set, a default summary will be shown based on DisplayKeys or all values.
 func (b *BobaBuilder) WithDisplayCallback(callback func() string) *BobaBuilder {
@@ -58,6 +168,78 @@ func (b *BobaBuilder) WithDisplayCallback(callback func() string) *BobaBuilder {
 	return b
 }
 
+// WithDefaults sets default values that are placed in the global data before the flow starts.
+// Defaults have the lowest precedence and are overridden by previous answers and environment variables.
+func (b *BobaBuilder) WithDefaults(defaults map[string]string) *BobaBuilder {
+	if b.config.Defaults == nil {
+		b.config.Defaults = make(map[string]string, len(defaults))
+	}
+	for key, value := range defaults {
+		b.config.Defaults[key] = value
+	}
+	return b
+}
+
+// WithEnvPrefix prefills global data from environment variables starting with prefix.
+// The remainder of the variable name is lowercased to form the key, so with prefix
+// "APP_" the variable APP_FIRST_NAME sets "first_name".
+func (b *BobaBuilder) WithEnvPrefix(prefix string) *BobaBuilder {
+	b.config.EnvPrefix = prefix
+	return b
+}
+
+// WithPreviousAnswers prefills global data from a JSON file written by an earlier run.
+// A missing file is not an error. When the flow finishes, its global values are saved back to the file.
+func (b *BobaBuilder) WithPreviousAnswers(path string) *BobaBuilder {
+	b.config.PreviousAnswersFile = path
+	return b
+}
+
+// WithTimeout limits how long the whole flow may run, and sets the action taken when
+// this or a form timeout expires.
+func (b *BobaBuilder) WithTimeout(timeout time.Duration, action TimeoutAction) *BobaBuilder {
+	b.config.Timeout = timeout
+	b.config.TimeoutAction = action
+	return b
+}
+
+// WithTimeoutAction sets the action taken when a flow or form timeout expires.
+func (b *BobaBuilder) WithTimeoutAction(action TimeoutAction) *BobaBuilder {
+	b.config.TimeoutAction = action
+	return b
+}
+
+// OnTimeout sets a callback that is called when a flow or form timeout expires.
+func (b *BobaBuilder) OnTimeout(hook FlowHook) *BobaBuilder {
+	b.config.OnTimeout = hook
+	return b
+}
+
+// WithVersion sets the version of the flow, stored with saved answers.
+// Answers saved by an older version are migrated when they are loaded.
+func (b *BobaBuilder) WithVersion(version int) *BobaBuilder {
+	b.config.Version = version
+	return b
+}
+
+// WithMigration registers a migration that upgrades stored values from the given version to the next.
+func (b *BobaBuilder) WithMigration(from int, migrate Migration) *BobaBuilder {
+	if b.config.Migrations == nil {
+		b.config.Migrations = make(map[int]Migration)
+	}
+	b.config.Migrations[from] = migrate
+	return b
+}
+
+// WithRecorder records the session to the given file.
+// The recording can be fed back into a flow with Bobarista.Replay to reproduce a user's path.
+// Every key press is written in plain text, including keys typed into password inputs,
+// unless the form is marked with WithSensitive.
+func (b *BobaBuilder) WithRecorder(path string) *BobaBuilder {
+	b.config.RecordFile = path
+	return b
+}
+
 // WithDebug enables or disables debug mode.
 // When enabled, a debug panel shows current form state, values, and navigation info.
 func (b *BobaBuilder) WithDebug(enabled bool) *BobaBuilder {
@@ -65,6 +247,36 @@ func (b *BobaBuilder) WithDebug(enabled bool) *BobaBuilder {
 	return b
 }
 
+// WithProvidedKeys declares global keys that are set outside of forms, for example by OnInit.
+// The flow analysis treats them as written before the first form.
+func (b *BobaBuilder) WithProvidedKeys(keys ...string) *BobaBuilder {
+	b.config.ProvidedKeys = append(b.config.ProvidedKeys, keys...)
+	return b
+}
+
+// WithStrictAnalysis enables or disables strict flow analysis.
+// When enabled, reads of keys no earlier form writes put the flow in the error state.
+func (b *BobaBuilder) WithStrictAnalysis(enabled bool) *BobaBuilder {
+	b.config.StrictAnalysis = enabled
+	return b
+}
+
+// WithAccessible enables or disables accessible mode.
+// Forms are asked as plain-text prompts, each announced with its name and step number,
+// and the summary is printed as plain lines.
+func (b *BobaBuilder) WithAccessible(enabled bool) *BobaBuilder {
+	b.config.Accessible = enabled
+	return b
+}
+
+// WithIO sets the reader and writer used instead of standard input and output.
+// This is mostly useful to drive accessible mode from scripts and tests.
+func (b *BobaBuilder) WithIO(in io.Reader, out io.Writer) *BobaBuilder {
+	b.config.Input = in
+	b.config.Output = out
+	return b
+}
+
 // Build creates and returns a new Bobarista instance with the configured settings.
 // This finalizes the builder and creates the form flow ready for execution.
 func (b *BobaBuilder) Build() *Bobarista {
@@ -72,7 +284,17 @@ func (b *BobaBuilder) Build() *Bobarista {
 		b.config.ColorScheme = "default"
 	}
 
-	return &Bobarista{
+	var themeErr error
+	if b.config.ThemesDir != "" {
+		_, themeErr = LoadColorSchemes(b.config.ThemesDir)
+	}
+
+	keys := DefaultKeyMap()
+	if b.config.KeyMap != nil {
+		keys = *b.config.KeyMap
+	}
+
+	boba := &Bobarista{
 		config:      b.config,
 		forms:       b.forms,
 		navigator:   NewNavigator(b.forms),
@@ -81,5 +303,26 @@ func (b *BobaBuilder) Build() *Bobarista {
 		currentForm: nil,
 		state:       StateActive,
 		errors:      make([]error, 0),
+		ownership:   newValueOwnership(),
+		keys:        keys,
+	}
+
+	if b.config.Debug {
+		boba.inspector = newInspector()
 	}
+
+	if themeErr != nil {
+		boba.addError("", themeErr)
+	}
+
+	for _, form := range b.forms {
+		for _, err := range form.exprErrs {
+			boba.addError(form.ID, err)
+		}
+	}
+
+	boba.analyze()
+	boba.publish()
+
+	return boba
 }
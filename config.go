@@ -1,5 +1,10 @@
 package bobarista
 
+import (
+	"io"
+	"time"
+)
+
 // Recipe holds the configuration settings for a Bobarista form flow.
 // It defines the appearance, behavior, and callback functions for the entire flow.
 type Recipe struct {
@@ -9,17 +14,67 @@ type Recipe struct {
 	// MaxWidth sets the maximum width in characters for the form display.
 	MaxWidth int
 
+	// Breakpoints control how the layout adapts to small terminals.
+	// Zero fields use the values from DefaultBreakpoints.
+	Breakpoints Breakpoints
+
 	// DisplayKeys specifies which form values to show in the completion summary.
 	// If empty, all non-empty values will be displayed.
 	DisplayKeys []string
 
+	// Derived defines global values computed from other global values.
+	Derived []DerivedValue
+
 	// ColorScheme determines the visual theme for the form flow.
-	// Available options: "default", "dark", "ubuntu", "ocean", "forest", "sunset", "monochrome".
+	// Available options: "default", "dark", "ubuntu", "ocean", "forest", "sunset", "monochrome",
+	// "auto", and any scheme loaded from a themes directory.
 	ColorScheme string
 
+	// Styles replaces the styles generated from the color scheme.
+	// If nil, styles are created from ColorScheme.
+	Styles *Styles
+
+	// StyleOverrides customize individual style elements.
+	// They are applied in order after the color scheme or Styles.
+	StyleOverrides []StyleOverride
+
+	// DisableFormTheme keeps the theme chosen by each FormGenerator instead of
+	// applying a huh theme derived from the color scheme.
+	DisableFormTheme bool
+
+	// ThemesDir is a directory of TOML, JSON, or YAML theme files registered when the flow is built.
+	ThemesDir string
+
+	// KeyMap replaces the default key bindings.
+	// If nil, DefaultKeyMap is used.
+	KeyMap *KeyMap
+
+	// ConfirmQuit asks for confirmation before the Quit binding aborts an active flow.
+	ConfirmQuit bool
+
+	// Locale selects the message catalog for framework-generated strings, such as "en", "de" or "ja".
+	// Use "auto" to pick it from the environment. Unknown locales fall back to English.
+	Locale string
+
+	// Messages override individual entries of the locale's message catalog.
+	Messages Messages
+
 	// Debug enables debug mode, showing additional information during form flow execution.
 	Debug bool
 
+	// Logger receives the flow's log messages. If nil, messages are written to
+	// the log file, and only in debug mode.
+	Logger Logger
+
+	// Accessible runs the flow as linear plain-text prompts without the alternate screen,
+	// for screen readers and terminals that cannot draw the full interface.
+	Accessible bool
+
+	// Input and Output replace standard input and output for the flow.
+	// If nil, os.Stdin and os.Stdout are used.
+	Input  io.Reader
+	Output io.Writer
+
 	// OnInit is called when the form flow initializes.
 	// It receives the Bobarista instance and initial form data for all forms.
 	OnInit func(*Bobarista, []FormData)
@@ -28,11 +83,112 @@ type Recipe struct {
 	// It receives the Bobarista instance with all collected data.
 	OnComplete func(*Bobarista) error
 
+	// OnFormEnter is called whenever any form in the flow is entered.
+	// It runs after the form's own OnEnter handler.
+	OnFormEnter FlowHook
+
+	// OnFormLeave is called whenever any form in the flow is left after completion.
+	// It runs after the form's own OnLeave handler.
+	OnFormLeave FlowHook
+
+	// OnFormSkip is called whenever any form in the flow is skipped.
+	// It runs after the form's own OnSkip handler.
+	OnFormSkip FlowHook
+
+	// Defaults are values placed in the global data before the flow starts.
+	Defaults map[string]string
+
+	// ProvidedKeys lists global keys set outside of forms, for example by OnInit.
+	// Together with Defaults they count as written before the first form during analysis.
+	ProvidedKeys []string
+
+	// StrictAnalysis puts the flow in the error state when the analysis run by Build
+	// finds reads of keys that no earlier form writes.
+	StrictAnalysis bool
+
+	// EnvPrefix enables prefilling global data from environment variables with this prefix.
+	// For example, with prefix "APP_" the variable APP_FIRST_NAME sets the key "first_name".
+	EnvPrefix string
+
+	// Timeout limits how long the whole flow may run. If zero, the flow has no timeout.
+	// Flow and form timeouts start with the first key press after the flow starts or is reset.
+	Timeout time.Duration
+
+	// TimeoutAction selects what happens when a flow or form timeout expires.
+	TimeoutAction TimeoutAction
+
+	// OnTimeout is called when a flow or form timeout expires, before the TimeoutAction runs.
+	// Returning an error puts the flow in the error state instead.
+	OnTimeout FlowHook
+
+	// Version identifies the shape of the flow's values. Increase it when keys are
+	// renamed or forms removed, and register a migration from the previous version.
+	Version int
+
+	// Migrations upgrade stored values, keyed by the version they migrate from.
+	Migrations map[int]Migration
+
+	// PreviousAnswersFile is a JSON file of answers from an earlier run.
+	// Its values prefill the global data, and the final values are saved back to it when the flow finishes.
+	PreviousAnswersFile string
+
+	// RecordFile enables session recording. Key presses, resizes and navigation
+	// decisions are appended to this file as JSON lines, for use with Replay.
+	RecordFile string
+
 	// DisplayCallback provides custom content for the completion screen.
 	// If nil, a default summary will be generated based on DisplayKeys.
 	DisplayCallback func() string
 }
 
+// Breakpoints define the terminal sizes at which the layout adapts.
+type Breakpoints struct {
+	// MinWidth and MinHeight are the smallest usable terminal size.
+	// Below either, a "terminal too small" screen is shown instead of the flow.
+	MinWidth  int
+	MinHeight int
+
+	// NarrowWidth is the width below which the debug panel is stacked below the form
+	// instead of beside it.
+	NarrowWidth int
+
+	// CompactHeight is the height below which the header is reduced to its title
+	// and the footer is hidden.
+	CompactHeight int
+}
+
+// DefaultBreakpoints returns the breakpoints used when none are configured.
+func DefaultBreakpoints() Breakpoints {
+	return Breakpoints{
+		MinWidth:      30,
+		MinHeight:     8,
+		NarrowWidth:   100,
+		CompactHeight: 16,
+	}
+}
+
+// withDefaults returns the breakpoints with zero fields replaced by their defaults.
+func (b Breakpoints) withDefaults() Breakpoints {
+	defaults := DefaultBreakpoints()
+	if b.MinWidth == 0 {
+		b.MinWidth = defaults.MinWidth
+	}
+	if b.MinHeight == 0 {
+		b.MinHeight = defaults.MinHeight
+	}
+	if b.NarrowWidth == 0 {
+		b.NarrowWidth = defaults.NarrowWidth
+	}
+	if b.CompactHeight == 0 {
+		b.CompactHeight = defaults.CompactHeight
+	}
+	return b
+}
+
+// FlowHook is a flow-level lifecycle callback.
+// It receives the Bobarista instance and the data of the form the event applies to.
+type FlowHook func(b *Bobarista, current *FormData) error
+
 // DefaultConfig returns a Recipe with sensible default values.
 // This provides a starting point for customization.
 func DefaultConfig() Recipe {
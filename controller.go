@@ -0,0 +1,172 @@
+package bobarista
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Controller drives a flow started with Start, or by a Launcher, from other goroutines.
+// Its methods send messages into the flow's tea.Program, so they are applied in
+// order with the user's input.
+type Controller struct {
+	flow   *Bobarista
+	done   chan struct{}
+	result Result
+}
+
+// Result describes how a flow started with Start ended.
+type Result struct {
+	// Snapshot is the state of the flow when it exited.
+	Snapshot
+
+	// Completed is true if the flow was finished, running its OnComplete callback.
+	Completed bool
+
+	// Err is the error returned by the tea.Program, or the last flow error if the flow
+	// ended in the error state. It is nil if the flow was completed or aborted without error.
+	Err error
+}
+
+// gotoMsg moves the flow to a form, sent by Controller.GoTo.
+type gotoMsg struct{ formID string }
+
+// abortMsg aborts the flow, sent by Controller.Abort.
+type abortMsg struct{ err error }
+
+// completeMsg finishes the flow, sent by Controller.Complete.
+type completeMsg struct{}
+
+// Start runs the form flow in the background and returns a Controller for it.
+// It fails with ErrAlreadyRunning if the flow is already running. In accessible mode
+// the flow runs without a tea.Program, so only Wait is available.
+func (f *Bobarista) Start() (*Controller, error) {
+	f.snapshotMu.Lock()
+	if f.running || f.program != nil {
+		f.snapshotMu.Unlock()
+		return nil, ErrAlreadyRunning
+	}
+	f.running = true
+	f.snapshotMu.Unlock()
+
+	c := &Controller{flow: f, done: make(chan struct{})}
+
+	if f.config.Accessible {
+		go func() {
+			defer close(c.done)
+			err := f.Run()
+			f.setRunning(false)
+			if errors.Is(err, ErrAborted) {
+				err = nil
+			}
+			c.result = f.result(err)
+		}()
+		return c, nil
+	}
+
+	f.infoLog("Starting Bobarista form flow in the background")
+	program := tea.NewProgram(f, programOptions(f.config)...)
+	f.setProgram(program)
+	go func() {
+		defer close(c.done)
+		_, err := program.Run()
+		f.setProgram(nil)
+		f.setRunning(false)
+		f.stopRecording()
+		if err != nil {
+			f.errorLog(fmt.Errorf("tea program error: %w", err))
+		}
+		f.infoLog("Bobarista form flow completed")
+		c.result = f.result(err)
+	}()
+	return c, nil
+}
+
+// result builds the Result of a flow that has exited.
+func (f *Bobarista) result(err error) Result {
+	snapshot := f.GetSnapshot()
+	if err == nil && snapshot.State == StateError && len(snapshot.Errors) > 0 {
+		err = snapshot.Errors[len(snapshot.Errors)-1]
+	}
+	return Result{
+		Snapshot:  snapshot,
+		Completed: f.finished,
+		Err:       err,
+	}
+}
+
+// GoTo moves the flow to the form with the given ID, bypassing its skip condition.
+// It fails with ErrFormNotFound if the flow has no such form.
+func (c *Controller) GoTo(formID string) error {
+	if _, _, err := c.flow.navigator.GetFormByID(formID); err != nil {
+		return fmt.Errorf("%w: '%s'", err, formID)
+	}
+	return c.flow.Send(gotoMsg{formID: formID})
+}
+
+// SetValue sets a global value in the running flow.
+func (c *Controller) SetValue(key, value string) error {
+	return c.flow.SendValue(key, value)
+}
+
+// Abort ends the flow without finishing it. A non-nil err is added to the flow's
+// errors and reported by Wait.
+func (c *Controller) Abort(err error) error {
+	return c.flow.Send(abortMsg{err: err})
+}
+
+// Complete finishes the flow with the values collected so far, as if the user had
+// reached and confirmed the completion screen.
+func (c *Controller) Complete() error {
+	return c.flow.Send(completeMsg{})
+}
+
+// Done returns a channel that is closed when the flow has exited.
+func (c *Controller) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait blocks until the flow has exited and returns its result.
+func (c *Controller) Wait() Result {
+	<-c.done
+	return c.result
+}
+
+// handleControl applies a message sent by a Controller.
+func (f *Bobarista) handleControl(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case gotoMsg:
+		f.confirmingQuit = false
+		cmd, err := f.jumpTo(msg.formID)
+		if err != nil {
+			f.errorLog(fmt.Errorf("controller jump failed: %w", err))
+			f.addError(msg.formID, err)
+			return nil
+		}
+		return cmd
+	case abortMsg:
+		f.infoLog("Flow aborted by controller")
+		if msg.err != nil {
+			formID := ""
+			if current := f.navigator.Current(); current != nil {
+				formID = current.ID
+			}
+			f.addError(formID, msg.err)
+		}
+		f.abortFlow()
+		return f.quit()
+	case completeMsg:
+		f.infoLog("Flow completed by controller")
+		if f.state == StateActive {
+			f.completeFlow()
+		}
+		if f.state == StateCompleted {
+			f.finish()
+		} else {
+			f.abortFlow()
+		}
+		return f.quit()
+	}
+	return nil
+}
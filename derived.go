@@ -0,0 +1,81 @@
+package bobarista
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeriveFunc computes a derived value from the global values.
+type DeriveFunc func(values *FormValues) string
+
+// DerivedValue defines a global key computed from other global keys.
+// It is recomputed whenever one of its inputs changes, and removed while none of them is set.
+type DerivedValue struct {
+	// Key is the global key the value is stored under.
+	Key string
+
+	// Inputs are the global keys the value is computed from.
+	// They may include keys of derived values defined earlier.
+	Inputs []string
+
+	// Compute returns the value from the current global values.
+	Compute DeriveFunc
+}
+
+// updateDerived recomputes the derived values whose inputs changed since they were last computed.
+// Derived values are processed in the order they were defined.
+func (f *Bobarista) updateDerived() {
+	if len(f.config.Derived) == 0 || f.globalData == nil {
+		return
+	}
+	if f.derivedInputs == nil {
+		f.derivedInputs = make(map[string]string, len(f.config.Derived))
+	}
+
+	values := f.globalData.Values
+	for _, derived := range f.config.Derived {
+		signature, anySet := derivedSignature(values, derived.Inputs)
+		if last, computed := f.derivedInputs[derived.Key]; computed && last == signature {
+			continue
+		}
+		f.derivedInputs[derived.Key] = signature
+
+		if !anySet {
+			f.debugLog(fmt.Sprintf("Removing derived value '%s', no inputs set", derived.Key))
+			values.Delete(derived.Key)
+			continue
+		}
+
+		value := derived.Compute(values)
+		f.debugLog(fmt.Sprintf("Derived '%s' = '%s'", derived.Key, value))
+		values.Set(derived.Key, value)
+	}
+}
+
+// derivedSignature encodes the current values of the inputs, and reports whether any of them is set.
+func derivedSignature(values *FormValues, inputs []string) (string, bool) {
+	var signature strings.Builder
+	anySet := false
+	for _, key := range inputs {
+		value, exists := values.Get(key)
+		if exists {
+			anySet = true
+			signature.WriteString("+")
+		} else {
+			signature.WriteString("-")
+		}
+		signature.WriteString(value)
+		signature.WriteString("\x00")
+	}
+	return signature.String(), anySet
+}
+
+// IsDerived returns true if the global key holds a derived value.
+func (f *Bobarista) IsDerived(key string) bool {
+	for _, derived := range f.config.Derived {
+		if derived.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,372 @@
+package bobarista
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiagramFormat selects the output language of a flow diagram.
+type DiagramFormat string
+
+const (
+	// DiagramDOT produces a Graphviz DOT digraph.
+	DiagramDOT DiagramFormat = "dot"
+	// DiagramMermaid produces a Mermaid flowchart.
+	DiagramMermaid DiagramFormat = "mermaid"
+)
+
+// Node IDs used for the start and end of the flow in diagrams.
+const (
+	diagramStart = "__start"
+	diagramEnd   = "__end"
+)
+
+// edgeKind describes why an edge appears in a flow diagram.
+type edgeKind int
+
+const (
+	// edgeNext is default navigation to the following form.
+	edgeNext edgeKind = iota
+	// edgeSkip is default navigation passing over forms with skip conditions.
+	edgeSkip
+	// edgeTarget is a navigation target declared on the form.
+	edgeTarget
+	// edgeRun was taken during a run but is not declared by the flow.
+	edgeRun
+)
+
+// diagramEdge is a possible transition between two forms, or the start or end of the flow.
+type diagramEdge struct {
+	from    string
+	to      string
+	kind    edgeKind
+	visited bool
+}
+
+// Diagram renders the forms, groups and navigation of the flow as a DOT or Mermaid diagram.
+// Forms with skip conditions are drawn dashed and declared navigation targets are drawn bold.
+// If path lists the IDs of the forms visited by a run, in order, its nodes and edges are highlighted.
+func (n *Navigator) Diagram(format DiagramFormat, path []string) (string, error) {
+	edges := n.diagramEdges()
+	visited := make(map[string]bool, len(path))
+
+	if len(path) > 0 {
+		steps := append([]string{diagramStart}, path...)
+		for i, id := range steps {
+			visited[id] = true
+			if i > 0 {
+				edges = markVisited(edges, steps[i-1], id)
+			}
+		}
+	}
+
+	switch format {
+	case DiagramDOT:
+		return n.renderDOT(edges, visited), nil
+	case DiagramMermaid:
+		return n.renderMermaid(edges, visited), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownDiagramFormat, format)
+	}
+}
+
+// Diagram renders the flow as a DOT or Mermaid diagram.
+// If overlayPath is true, the forms visited so far are highlighted, ending at the
+// completion node once the flow has completed.
+func (f *Bobarista) Diagram(format DiagramFormat, overlayPath bool) (string, error) {
+	var path []string
+	if overlayPath {
+		path = f.GetPath()
+		if f.state == StateCompleted {
+			path = append(path, diagramEnd)
+		}
+	}
+	return f.navigator.Diagram(format, path)
+}
+
+// GetPath returns the IDs of the forms visited so far, oldest first, ending with the current form.
+func (f *Bobarista) GetPath() []string {
+	var path []string
+	for _, index := range f.navigator.GetHistory() {
+		path = append(path, f.forms[index].ID)
+	}
+	if current := f.navigator.Current(); current != nil {
+		path = append(path, current.ID)
+	}
+	return path
+}
+
+// diagramEdges collects the edges of the flow: default navigation, including the
+// edges that pass over forms with skip conditions, and declared navigation targets.
+func (n *Navigator) diagramEdges() []diagramEdge {
+	var edges []diagramEdge
+	seen := make(map[[2]string]bool)
+	add := func(from, to string, kind edgeKind) {
+		if seen[[2]string{from, to}] {
+			return
+		}
+		seen[[2]string{from, to}] = true
+		edges = append(edges, diagramEdge{from: from, to: to, kind: kind})
+	}
+
+	for _, edge := range n.defaultSuccessors(-1, diagramStart) {
+		add(edge.from, edge.to, edge.kind)
+	}
+	for i, form := range n.forms {
+		for _, target := range form.declaredTargets() {
+			add(form.ID, target, edgeTarget)
+		}
+		for _, edge := range n.defaultSuccessors(i, form.ID) {
+			add(edge.from, edge.to, edge.kind)
+		}
+	}
+	return edges
+}
+
+// defaultSuccessors returns the edges default navigation can take after the form at index.
+// Each form with a skip condition may be passed over, so the form after it is reachable too.
+func (n *Navigator) defaultSuccessors(index int, from string) []diagramEdge {
+	var edges []diagramEdge
+	kind := edgeNext
+	for i := index + 1; ; i++ {
+		if i >= len(n.forms) {
+			return append(edges, diagramEdge{from: from, to: diagramEnd, kind: kind})
+		}
+		edges = append(edges, diagramEdge{from: from, to: n.forms[i].ID, kind: kind})
+		if n.forms[i].ShouldSkip == nil {
+			return edges
+		}
+		kind = edgeSkip
+	}
+}
+
+// markVisited highlights the edge between two nodes, adding it if the flow does not declare it.
+func markVisited(edges []diagramEdge, from, to string) []diagramEdge {
+	for i := range edges {
+		if edges[i].from == from && edges[i].to == to {
+			edges[i].visited = true
+			return edges
+		}
+	}
+	return append(edges, diagramEdge{from: from, to: to, kind: edgeRun, visited: true})
+}
+
+// diagramGroups returns the group names in order of first appearance.
+func (n *Navigator) diagramGroups() []string {
+	var groups []string
+	seen := make(map[string]bool)
+	for _, form := range n.forms {
+		if form.Group != "" && !seen[form.Group] {
+			seen[form.Group] = true
+			groups = append(groups, form.Group)
+		}
+	}
+	return groups
+}
+
+// diagramLabel returns the label of a form node.
+func diagramLabel(form Form) string {
+	if form.Name != "" {
+		return form.Name
+	}
+	return form.ID
+}
+
+// renderDOT renders the diagram as a Graphviz digraph.
+func (n *Navigator) renderDOT(edges []diagramEdge, visited map[string]bool) string {
+	var out strings.Builder
+	out.WriteString("digraph flow {\n")
+	out.WriteString("\trankdir=TB;\n")
+	out.WriteString("\tnode [shape=box, style=rounded];\n")
+	out.WriteString(fmt.Sprintf("\t%s [label=\"start\", shape=circle%s];\n",
+		strconv.Quote(diagramStart), dotVisitedFill(visited[diagramStart])))
+	out.WriteString(fmt.Sprintf("\t%s [label=\"end\", shape=doublecircle%s];\n",
+		strconv.Quote(diagramEnd), dotVisitedFill(visited[diagramEnd])))
+
+	node := func(indent string, form Form) {
+		style := []string{"rounded"}
+		if form.ShouldSkip != nil {
+			style = append(style, "dashed")
+		}
+		fill := ""
+		if visited[form.ID] {
+			style = append(style, "filled")
+			fill = ", fillcolor=\"lightblue\""
+		}
+		out.WriteString(fmt.Sprintf("%s%s [label=%s, style=%s%s];\n", indent,
+			strconv.Quote(form.ID), strconv.Quote(diagramLabel(form)),
+			strconv.Quote(strings.Join(style, ",")), fill))
+	}
+
+	for i, group := range n.diagramGroups() {
+		out.WriteString(fmt.Sprintf("\tsubgraph \"cluster_%d\" {\n", i))
+		out.WriteString(fmt.Sprintf("\t\tlabel=%s;\n", strconv.Quote(group)))
+		for _, form := range n.forms {
+			if form.Group == group {
+				node("\t\t", form)
+			}
+		}
+		out.WriteString("\t}\n")
+	}
+	for _, form := range n.forms {
+		if form.Group == "" {
+			node("\t", form)
+		}
+	}
+
+	for _, edge := range edges {
+		var attrs []string
+		switch edge.kind {
+		case edgeSkip:
+			attrs = append(attrs, "style=dashed", "label=\"skip\"")
+		case edgeTarget:
+			attrs = append(attrs, "style=bold")
+		case edgeRun:
+			attrs = append(attrs, "style=dotted")
+		}
+		if edge.visited {
+			attrs = append(attrs, "color=\"red\"", "penwidth=2")
+		}
+
+		out.WriteString(fmt.Sprintf("\t%s -> %s", strconv.Quote(edge.from), strconv.Quote(edge.to)))
+		if len(attrs) > 0 {
+			out.WriteString(" [" + strings.Join(attrs, ", ") + "]")
+		}
+		out.WriteString(";\n")
+	}
+
+	out.WriteString("}\n")
+	return out.String()
+}
+
+// dotVisitedFill returns the DOT attributes filling a visited start or end node.
+func dotVisitedFill(visited bool) string {
+	if visited {
+		return ", style=filled, fillcolor=\"lightblue\""
+	}
+	return ""
+}
+
+// renderMermaid renders the diagram as a Mermaid flowchart.
+func (n *Navigator) renderMermaid(edges []diagramEdge, visited map[string]bool) string {
+	var out strings.Builder
+	out.WriteString("flowchart TD\n")
+	out.WriteString(fmt.Sprintf("    %s((start))\n", diagramStart))
+	out.WriteString(fmt.Sprintf("    %s(((end)))\n", diagramEnd))
+
+	nodeID := n.mermaidIDs()
+	node := func(indent string, form Form) {
+		out.WriteString(fmt.Sprintf("%s%s[\"%s\"]", indent, nodeID(form.ID), mermaidText(diagramLabel(form))))
+		if form.ShouldSkip != nil {
+			out.WriteString(":::conditional")
+		}
+		out.WriteString("\n")
+	}
+
+	for i, group := range n.diagramGroups() {
+		out.WriteString(fmt.Sprintf("    subgraph group_%d [\"%s\"]\n", i, mermaidText(group)))
+		for _, form := range n.forms {
+			if form.Group == group {
+				node("        ", form)
+			}
+		}
+		out.WriteString("    end\n")
+	}
+	for _, form := range n.forms {
+		if form.Group == "" {
+			node("    ", form)
+		}
+	}
+
+	var visitedEdges []string
+	for i, edge := range edges {
+		arrow := "-->"
+		switch edge.kind {
+		case edgeSkip:
+			arrow = "-. skip .->"
+		case edgeTarget:
+			arrow = "==>"
+		case edgeRun:
+			arrow = "-.->"
+		}
+		out.WriteString(fmt.Sprintf("    %s %s %s\n", nodeID(edge.from), arrow, nodeID(edge.to)))
+		if edge.visited {
+			visitedEdges = append(visitedEdges, strconv.Itoa(i))
+		}
+	}
+
+	out.WriteString("    classDef conditional stroke-dasharray: 5 5\n")
+	if len(visited) > 0 {
+		var nodes []string
+		for _, id := range append([]string{diagramStart, diagramEnd}, n.formIDs()...) {
+			if visited[id] {
+				nodes = append(nodes, nodeID(id))
+			}
+		}
+		out.WriteString("    classDef visited fill:#cde4ff,stroke:#1f6feb\n")
+		out.WriteString(fmt.Sprintf("    class %s visited\n", strings.Join(nodes, ",")))
+	}
+	if len(visitedEdges) > 0 {
+		out.WriteString(fmt.Sprintf("    linkStyle %s stroke:#d73a49,stroke-width:2px\n", strings.Join(visitedEdges, ",")))
+	}
+
+	return out.String()
+}
+
+// formIDs returns the IDs of all forms in order.
+func (n *Navigator) formIDs() []string {
+	ids := make([]string, len(n.forms))
+	for i, form := range n.forms {
+		ids[i] = form.ID
+	}
+	return ids
+}
+
+// mermaidID converts a form ID into a Mermaid node ID.
+// Form IDs are prefixed so they cannot clash with keywords such as "end".
+func mermaidID(id string) string {
+	if id == diagramStart || id == diagramEnd {
+		return id
+	}
+	var out strings.Builder
+	out.WriteString("form_")
+	for _, r := range id {
+		if r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			out.WriteRune(r)
+		} else {
+			out.WriteRune('_')
+		}
+	}
+	return out.String()
+}
+
+// mermaidIDs returns a function mapping form IDs to unique Mermaid node IDs.
+// Form IDs that sanitize to an ID already in use get a numeric suffix, assigned
+// in form order so the output is stable.
+func (n *Navigator) mermaidIDs() func(string) string {
+	ids := make(map[string]string)
+	used := make(map[string]bool)
+	nodeID := func(id string) string {
+		if nodeID, ok := ids[id]; ok {
+			return nodeID
+		}
+		base := mermaidID(id)
+		nodeID := base
+		for i := 2; used[nodeID]; i++ {
+			nodeID = fmt.Sprintf("%s_%d", base, i)
+		}
+		ids[id] = nodeID
+		used[nodeID] = true
+		return nodeID
+	}
+	for _, form := range n.forms {
+		nodeID(form.ID)
+	}
+	return nodeID
+}
+
+// mermaidText escapes a label for use inside a quoted Mermaid string.
+func mermaidText(text string) string {
+	return strings.ReplaceAll(text, "\"", "#quot;")
+}
@@ -28,6 +28,54 @@ var (
 
 	// ErrEmptyFormID is returned when a form has an empty ID.
 	ErrEmptyFormID = errors.New("form ID cannot be empty")
+
+	// ErrLoadTimeout is returned when a form loader does not finish within its timeout.
+	ErrLoadTimeout = errors.New("form loader timed out")
+
+	// ErrUnknownThemeFormat is returned when a theme file has an unsupported format.
+	ErrUnknownThemeFormat = errors.New("unknown theme format")
+
+	// ErrMissingThemeColor is returned when a theme file does not define a required color.
+	ErrMissingThemeColor = errors.New("theme color is missing")
+
+	// ErrUnknownNavigationTarget is returned when a form declares a navigation target that does not exist.
+	ErrUnknownNavigationTarget = errors.New("unknown navigation target")
+
+	// ErrUnknownDiagramFormat is returned when a flow diagram is requested in an unsupported format.
+	ErrUnknownDiagramFormat = errors.New("unknown diagram format")
+
+	// ErrInvalidExpression is returned when a condition expression cannot be parsed.
+	ErrInvalidExpression = errors.New("invalid expression")
+
+	// ErrUnsupportedVersion is returned when stored values come from a newer version of the flow.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+
+	// ErrNotRunning is returned when a message is sent to a flow that is not running.
+	ErrNotRunning = errors.New("flow is not running")
+
+	// ErrAborted is returned by Run in accessible mode when the flow ends without completing.
+	ErrAborted = errors.New("flow aborted")
+
+	// ErrAlreadyRunning is returned when a flow that is already running is started again.
+	ErrAlreadyRunning = errors.New("flow is already running")
+
+	// ErrAccessibleLauncher is returned when a Launcher is run with a flow built for accessible mode.
+	ErrAccessibleLauncher = errors.New("launcher does not support accessible flows")
+
+	// ErrReplayingRecordFile is returned when a flow is replayed from the file it records to.
+	ErrReplayingRecordFile = errors.New("cannot replay the file the flow records to")
+
+	// ErrTimeout is returned when a form or flow timeout expires with TimeoutAbort.
+	ErrTimeout = errors.New("timed out")
+
+	// ErrUnwrittenRead is reported when a form reads a key that no form able to run before it writes.
+	ErrUnwrittenRead = errors.New("reads a key no earlier form writes")
+
+	// ErrUnusedWrite is reported when a form writes a key that no form or summary reads.
+	ErrUnusedWrite = errors.New("writes a key nothing reads")
+
+	// ErrNavigationCycle is reported when navigation can return to a form it has already left.
+	ErrNavigationCycle = errors.New("navigation cycle")
 )
 
 // CupSleeveError represents an error that occurred within a specific form.
@@ -0,0 +1,153 @@
+package bobarista
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType identifies the kind of state change reported by an Event.
+type EventType int
+
+const (
+	// EventFlowStarted is emitted when the form flow initializes.
+	EventFlowStarted EventType = iota
+	// EventFormEntered is emitted when a form is entered and about to be displayed.
+	EventFormEntered
+	// EventFormSkipped is emitted when a form is skipped by its skip condition.
+	EventFormSkipped
+	// EventFormCompleted is emitted when a form is completed and its values are merged.
+	EventFormCompleted
+	// EventNavigationDecided is emitted when the next form has been determined.
+	EventNavigationDecided
+	// EventErrorRaised is emitted when an error is added to the flow.
+	EventErrorRaised
+	// EventFlowCompleted is emitted when the flow reaches the completed state.
+	EventFlowCompleted
+	// EventFlowAborted is emitted when the user quits before finishing the flow.
+	EventFlowAborted
+	// EventTimedOut is emitted when a form or flow timeout expires, before its action runs.
+	EventTimedOut
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventFlowStarted:
+		return "FlowStarted"
+	case EventFormEntered:
+		return "FormEntered"
+	case EventFormSkipped:
+		return "FormSkipped"
+	case EventFormCompleted:
+		return "FormCompleted"
+	case EventNavigationDecided:
+		return "NavigationDecided"
+	case EventErrorRaised:
+		return "ErrorRaised"
+	case EventFlowCompleted:
+		return "FlowCompleted"
+	case EventFlowAborted:
+		return "FlowAborted"
+	case EventTimedOut:
+		return "TimedOut"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(t))
+	}
+}
+
+// Event describes a state change in a Bobarista form flow.
+// Events are delivered synchronously to subscribers in the order they occur.
+type Event struct {
+	// Type identifies the kind of event.
+	Type EventType
+
+	// Time is when the event occurred.
+	Time time.Time
+
+	// FormID is the form the event applies to, or empty for flow-level events.
+	FormID string
+
+	// NextFormID is the destination form for EventNavigationDecided.
+	// It is empty when navigation completes the flow.
+	NextFormID string
+
+	// Err is the error for EventErrorRaised, or the last error for EventFlowAborted.
+	Err error
+}
+
+// EventHandler receives events emitted by a Bobarista form flow.
+type EventHandler func(Event)
+
+// subscription pairs an event handler with the ID used to unsubscribe it.
+type subscription struct {
+	id      int
+	handler EventHandler
+}
+
+// Subscribe registers a handler that receives every event emitted by the flow.
+// Handlers are called synchronously, in subscription order, from the goroutine
+// running the flow. The returned function removes the subscription.
+func (f *Bobarista) Subscribe(handler EventHandler) func() {
+	f.nextSubID++
+	id := f.nextSubID
+	f.subscribers = append(f.subscribers, subscription{id: id, handler: handler})
+
+	return func() {
+		for i, sub := range f.subscribers {
+			if sub.id == id {
+				f.subscribers = append(f.subscribers[:i:i], f.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// maxEventLog is the number of recent events kept for the debug inspector.
+const maxEventLog = 200
+
+// emit timestamps the event and delivers it to all subscribers.
+// In debug mode the event is also kept in the event log shown by the inspector.
+func (f *Bobarista) emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if f.config.Debug {
+		f.eventLog = append(f.eventLog, event)
+		if len(f.eventLog) > maxEventLog {
+			f.eventLog = f.eventLog[len(f.eventLog)-maxEventLog:]
+		}
+	}
+	for _, sub := range f.subscribers {
+		sub.handler(event)
+	}
+}
+
+// completeFlow transitions the flow to the completed state and reports it.
+// Values contributed by forms that are no longer on the path are retracted first.
+func (f *Bobarista) completeFlow() {
+	f.retractOffPath()
+	f.state = StateCompleted
+	f.emit(Event{Type: EventFlowCompleted})
+}
+
+// abortFlow reports that the user left the flow before finishing it.
+func (f *Bobarista) abortFlow() {
+	var lastErr error
+	if len(f.errors) > 0 {
+		lastErr = f.errors[len(f.errors)-1]
+	}
+	formID := ""
+	if current := f.navigator.Current(); current != nil {
+		formID = current.ID
+	}
+	f.emit(Event{Type: EventFlowAborted, FormID: formID, Err: lastErr})
+}
+
+// emitNavigation reports the navigation decision made after the given form.
+func (f *Bobarista) emitNavigation(fromID string, nextIndex int) {
+	nextID := ""
+	if nextIndex >= 0 && nextIndex < len(f.navigator.forms) {
+		nextID = f.navigator.forms[nextIndex].ID
+	}
+	f.emit(Event{Type: EventNavigationDecided, FormID: fromID, NextFormID: nextID})
+}
@@ -0,0 +1,468 @@
+package bobarista
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a parsed condition over FormValues, used by WithSkipWhen and WithNavigationRule.
+//
+// Identifiers name keys and evaluate to their value, or "" if unset. A "global."
+// prefix, as in global.user_type, is accepted and refers to the same key. Literals
+// are quoted strings ('a' or "a"), numbers, true and false. Supported operators:
+//
+//	== != < <= > >=     compare numerically if both sides are numbers, as strings otherwise
+//	in, not in          membership in a list: plan in ['pro', 'team']
+//	=~ !~               regular expression match against a string literal
+//	and or not          also written && || !
+//	empty(key)          true if the key is unset or ""
+//
+// A bare operand is true unless it is "", "false" or "0".
+type Expr struct {
+	source string
+	root   exprNode
+	keys   []string
+}
+
+// ParseExpr parses a condition expression.
+// Errors wrap ErrInvalidExpression and report the byte offset of the problem.
+func ParseExpr(source string) (*Expr, error) {
+	tokens, err := lexExpr(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{source: source, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+
+	return &Expr{source: source, root: root, keys: p.keys}, nil
+}
+
+// Eval evaluates the expression against the given values.
+func (e *Expr) Eval(values *FormValues) bool {
+	if values == nil {
+		values = NewFormValues()
+	}
+	return e.root.truth(values)
+}
+
+// Keys returns the keys the expression reads, in order of first appearance.
+func (e *Expr) Keys() []string {
+	keys := make([]string, len(e.keys))
+	copy(keys, e.keys)
+	return keys
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// exprNode is a node of a parsed expression.
+// Every node has a string value and a truth value.
+type exprNode interface {
+	value(values *FormValues) string
+	truth(values *FormValues) bool
+}
+
+// literalNode is a string, number or boolean literal.
+type literalNode struct{ text string }
+
+func (n literalNode) value(*FormValues) string      { return n.text }
+func (n literalNode) truth(values *FormValues) bool { return truthy(n.text) }
+
+// keyNode reads a key from the values.
+type keyNode struct{ key string }
+
+func (n keyNode) value(values *FormValues) string {
+	value, _ := values.Get(n.key)
+	return value
+}
+
+func (n keyNode) truth(values *FormValues) bool { return truthy(n.value(values)) }
+
+// boolNode is a node whose value is its truth value.
+type boolNode struct{ test func(values *FormValues) bool }
+
+func (n boolNode) value(values *FormValues) string { return strconv.FormatBool(n.test(values)) }
+func (n boolNode) truth(values *FormValues) bool   { return n.test(values) }
+
+// truthy reports whether an operand counts as true.
+func truthy(value string) bool {
+	return value != "" && value != "false" && value != "0"
+}
+
+// compareValues orders two operands, numerically if both are numbers.
+func compareValues(left, right string) int {
+	l, lerr := strconv.ParseFloat(left, 64)
+	r, rerr := strconv.ParseFloat(right, 64)
+	if lerr == nil && rerr == nil {
+		switch {
+		case l < r:
+			return -1
+		case l > r:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(left, right)
+}
+
+// exprTokenKind identifies the kind of an expression token.
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+// exprToken is a lexed token with its byte offset in the source.
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+// String describes the token for error messages.
+func (t exprToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// exprGlobalPrefix is the optional prefix of keys, matching the global values a skip condition receives.
+const exprGlobalPrefix = "global."
+
+// exprOperators lists the operator tokens, longest first.
+var exprOperators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","}
+
+// lexExpr splits the source into tokens.
+func lexExpr(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(source)
+	offsets := make([]int, len(runes)+1)
+	for i, offset := 0, 0; i < len(runes); i++ {
+		offsets[i] = offset
+		offset += len(string(runes[i]))
+		offsets[i+1] = offset
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '\'' || r == '"':
+			start := i
+			var text strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				text.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("%w at %d: unterminated string", ErrInvalidExpression, offsets[start])
+			}
+			i++
+			tokens = append(tokens, exprToken{kind: tokString, text: text.String(), pos: offsets[start]})
+
+		case unicode.IsDigit(r) || r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, exprToken{kind: tokNumber, text: string(runes[start:i]), pos: offsets[start]})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i++; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.'); i++ {
+			}
+			tokens = append(tokens, exprToken{kind: tokIdent, text: string(runes[start:i]), pos: offsets[start]})
+
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, exprToken{kind: tokOp, text: op, pos: offsets[i]})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("%w at %d: unexpected character %q", ErrInvalidExpression, offsets[i], r)
+			}
+		}
+	}
+
+	return append(tokens, exprToken{kind: tokEOF, pos: len(source)}), nil
+}
+
+// exprParser is a recursive descent parser over the lexed tokens.
+type exprParser struct {
+	source string
+	tokens []exprToken
+	pos    int
+	keys   []string
+}
+
+// peek returns the next token without consuming it.
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the next token.
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the given operators or keywords.
+func (p *exprParser) accept(texts ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp && tok.kind != tokIdent {
+		return "", false
+	}
+	for _, text := range texts {
+		if tok.text == text {
+			p.pos++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// expect consumes the given operator or fails.
+func (p *exprParser) expect(text string) error {
+	if _, ok := p.accept(text); !ok {
+		return p.errorf(p.peek(), "expected '%s', found %s", text, p.peek())
+	}
+	return nil
+}
+
+// errorf returns an ErrInvalidExpression error at the position of the token.
+func (p *exprParser) errorf(tok exprToken, format string, args ...any) error {
+	return fmt.Errorf("%w at %d: %s", ErrInvalidExpression, tok.pos, fmt.Sprintf(format, args...))
+}
+
+// parseOr parses: and ("or" and)*
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("or", "||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = boolNode{func(values *FormValues) bool { return l.truth(values) || right.truth(values) }}
+	}
+}
+
+// parseAnd parses: not ("and" not)*
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("and", "&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = boolNode{func(values *FormValues) bool { return l.truth(values) && right.truth(values) }}
+	}
+}
+
+// parseNot parses: ("not" | "!") not | comparison
+func (p *exprParser) parseNot() (exprNode, error) {
+	if _, ok := p.accept("not", "!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return boolNode{func(values *FormValues) bool { return !operand.truth(values) }}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses an operand optionally followed by a comparison, membership or match.
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	negate := false
+	if p.peek().kind == tokIdent && p.peek().text == "not" &&
+		p.tokens[p.pos+1].kind == tokIdent && p.tokens[p.pos+1].text == "in" {
+		p.pos++
+		negate = true
+	}
+
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "=~", "!~", "in")
+	if !ok {
+		return left, nil
+	}
+
+	switch op {
+	case "in":
+		items, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return boolNode{func(values *FormValues) bool {
+			value := left.value(values)
+			for _, item := range items {
+				if compareValues(value, item.value(values)) == 0 {
+					return !negate
+				}
+			}
+			return negate
+		}}, nil
+
+	case "=~", "!~":
+		tok := p.next()
+		if tok.kind != tokString {
+			return nil, p.errorf(tok, "expected a string pattern after '%s', found %s", op, tok)
+		}
+		re, err := regexp.Compile(tok.text)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid pattern: %v", err)
+		}
+		match := op == "=~"
+		return boolNode{func(values *FormValues) bool {
+			return re.MatchString(left.value(values)) == match
+		}}, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return boolNode{func(values *FormValues) bool {
+		c := compareValues(left.value(values), right.value(values))
+		switch op {
+		case "==":
+			return c == 0
+		case "!=":
+			return c != 0
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		default:
+			return c >= 0
+		}
+	}}, nil
+}
+
+// parseList parses: "[" operand ("," operand)* "]"
+func (p *exprParser) parseList() ([]exprNode, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var items []exprNode
+	if _, ok := p.accept("]"); ok {
+		return items, nil
+	}
+	for {
+		item, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	return items, p.expect("]")
+}
+
+// parseOperand parses a literal, key, empty() check or parenthesized expression.
+func (p *exprParser) parseOperand() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokString, tokNumber:
+		return literalNode{tok.text}, nil
+
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			return literalNode{tok.text}, nil
+		case "empty":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			operand, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return boolNode{func(values *FormValues) bool { return operand.value(values) == "" }}, nil
+		case "and", "or", "not", "in":
+			return nil, p.errorf(tok, "unexpected %s", tok)
+		}
+		key := strings.TrimPrefix(tok.text, exprGlobalPrefix)
+		if key == "" {
+			return nil, p.errorf(tok, "expected a key after '%s'", exprGlobalPrefix)
+		}
+		p.addKey(key)
+		return keyNode{key}, nil
+
+	case tokOp:
+		if tok.text == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		}
+	}
+	return nil, p.errorf(tok, "unexpected %s", tok)
+}
+
+// addKey records a key read by the expression.
+func (p *exprParser) addKey(key string) {
+	for _, existing := range p.keys {
+		if existing == key {
+			return
+		}
+	}
+	p.keys = append(p.keys, key)
+}
@@ -1,6 +1,11 @@
 package bobarista
 
-import "github.com/charmbracelet/huh"
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
 
 // Form represents a single form in the Bobarista form flow.
 // It contains the form's metadata, generator function, and behavior callbacks.
@@ -26,18 +31,89 @@ type Form struct {
 	// NextForm provides custom navigation logic to determine the next form.
 	NextForm NavigationHandler
 
+	// NavigationTargets declares the IDs of the forms NextForm may navigate to.
+	// They are used for validation and flow diagrams, since NextForm itself is opaque.
+	NavigationTargets []string
+
+	// Reads declares the global keys the form depends on, for example in its skip condition.
+	// Declared reads and writes are checked when the flow is built.
+	Reads []string
+
+	// Writes declares the global keys the form sets.
+	Writes []string
+
+	// Sensitive marks forms that ask for secrets. Characters typed while the
+	// form is shown are recorded as '*' by WithRecorder.
+	Sensitive bool
+
 	// ShowStatus controls whether this form shows progress status in the UI.
 	ShowStatus bool
+
+	// OnEnter is called when the form is entered, before it is generated.
+	// It can be used to prefill the form's values.
+	OnEnter LifecycleHandler
+
+	// OnLeave is called when the form is left after being completed.
+	OnLeave LifecycleHandler
+
+	// OnSkip is called when the form is skipped by a skip condition.
+	// It can be used to clean up values the form contributed earlier.
+	OnSkip LifecycleHandler
+
+	// Loader fetches data needed by the form before it is generated.
+	// While the loader runs, a loading view is displayed in place of the form.
+	Loader FormLoader
+
+	// LoadedGenerator creates the huh.Form once the Loader has delivered its data.
+	// It is used instead of Generator when a Loader is set.
+	LoadedGenerator LoadedFormGenerator
+
+	// LoadTimeout limits how long the Loader may run before the flow fails.
+	// If zero, DefaultLoadTimeout is used.
+	LoadTimeout time.Duration
+
+	// Timeout limits how long the form may sit idle before the flow's
+	// TimeoutAction runs. If zero, the form has no timeout.
+	Timeout time.Duration
+
+	// SkipWhen is the expression set by WithSkipWhen, if any.
+	SkipWhen *Expr
+
+	// NavigationRules are checked in order after the form completes; the first
+	// rule whose condition holds selects the next form.
+	NavigationRules []NavigationRule
+
+	// exprErrs collects expressions that failed to parse, reported when the flow is built.
+	exprErrs []error
+}
+
+// NavigationRule moves to the Target form when its When expression holds.
+type NavigationRule struct {
+	When   *Expr
+	Target string
 }
 
 // FormGenerator is a function that creates a huh.Form instance.
 // It receives the current form's values and global values to customize the form.
 type FormGenerator func(current *FormValues, global *FormValues) *huh.Form
 
+// FormLoader fetches data for a form before it is displayed.
+// The returned tea.Cmd is executed asynchronously; the message it produces is passed
+// to the form's LoadedGenerator, or the flow fails if the message is an error.
+type FormLoader func(current *FormValues, global *FormValues) tea.Cmd
+
+// LoadedFormGenerator is a function that creates a huh.Form instance from loaded data.
+// It receives the current form's values, global values, and the data produced by the form's Loader.
+type LoadedFormGenerator func(current *FormValues, global *FormValues, data any) *huh.Form
+
 // CompletionHandler is called when a form is completed successfully.
 // It receives the current form's data and global data, and can return an error to halt the flow.
 type CompletionHandler func(current *FormData, global *FormData) error
 
+// LifecycleHandler is called when a form is entered, left, or skipped.
+// It receives the form's data and global data, and can return an error to halt the flow.
+type LifecycleHandler func(current *FormData, global *FormData) error
+
 // SkipCondition determines whether a form should be skipped.
 // It receives the current form's data and global data, returning true to skip the form.
 type SkipCondition func(current *FormData, global *FormData) bool
@@ -57,6 +133,13 @@ func NewForm(id, name string) Form {
 	}
 }
 
+// WithGroup sets the group the form belongs to.
+// Groups organize related forms and are drawn as clusters in flow diagrams.
+func (f Form) WithGroup(group string) Form {
+	f.Group = group
+	return f
+}
+
 // WithGenerator sets the form generator function.
 // The generator is responsible for creating the actual huh.Form instance.
 func (f Form) WithGenerator(gen FormGenerator) Form {
@@ -64,6 +147,20 @@ func (f Form) WithGenerator(gen FormGenerator) Form {
 	return f
 }
 
+// WithLoader sets an asynchronous loader and the generator that consumes its data.
+// The loader runs each time the form is entered, before the form is generated.
+func (f Form) WithLoader(loader FormLoader, gen LoadedFormGenerator) Form {
+	f.Loader = loader
+	f.LoadedGenerator = gen
+	return f
+}
+
+// WithLoadTimeout sets how long the form's loader may run before the flow fails.
+func (f Form) WithLoadTimeout(timeout time.Duration) Form {
+	f.LoadTimeout = timeout
+	return f
+}
+
 // WithOnComplete sets the completion handler for the form.
 // This handler is called when the form is successfully completed.
 func (f Form) WithOnComplete(handler CompletionHandler) Form {
@@ -71,10 +168,67 @@ func (f Form) WithOnComplete(handler CompletionHandler) Form {
 	return f
 }
 
+// WithOnEnter sets the handler called when the form is entered.
+// The handler runs before the form is generated, so values it sets are visible to the generator.
+func (f Form) WithOnEnter(handler LifecycleHandler) Form {
+	f.OnEnter = handler
+	return f
+}
+
+// WithOnLeave sets the handler called when the form is left after completion.
+// The handler runs after the form's values are merged into global data.
+func (f Form) WithOnLeave(handler LifecycleHandler) Form {
+	f.OnLeave = handler
+	return f
+}
+
+// WithOnSkip sets the handler called when the form is skipped.
+func (f Form) WithOnSkip(handler LifecycleHandler) Form {
+	f.OnSkip = handler
+	return f
+}
+
 // WithSkipCondition sets the skip condition for the form.
 // The form will be skipped if the condition returns true.
+// It replaces any expression set by WithSkipWhen.
 func (f Form) WithSkipCondition(condition SkipCondition) Form {
 	f.ShouldSkip = condition
+	f.SkipWhen = nil
+	return f
+}
+
+// WithTimeout limits how long the form may sit idle; each key press restarts it.
+// When it expires, the flow's OnTimeout hook and TimeoutAction run.
+func (f Form) WithTimeout(timeout time.Duration) Form {
+	f.Timeout = timeout
+	return f
+}
+
+// WithSkipWhen sets an expression as the skip condition for the form, such as
+// "user_type != 'company'". It is evaluated against the global values.
+func (f Form) WithSkipWhen(expression string) Form {
+	expr, err := ParseExpr(expression)
+	if err != nil {
+		f.exprErrs = append(f.exprErrs[:len(f.exprErrs):len(f.exprErrs)], err)
+		return f
+	}
+	f.SkipWhen = expr
+	f.ShouldSkip = func(current *FormData, global *FormData) bool {
+		return expr.Eval(global.Values)
+	}
+	return f
+}
+
+// WithNavigationRule adds a rule moving to the target form when the expression holds.
+// Rules are checked in order before the navigation handler and default navigation.
+func (f Form) WithNavigationRule(expression, target string) Form {
+	expr, err := ParseExpr(expression)
+	if err != nil {
+		f.exprErrs = append(f.exprErrs[:len(f.exprErrs):len(f.exprErrs)], err)
+		return f
+	}
+	rules := f.NavigationRules[:len(f.NavigationRules):len(f.NavigationRules)]
+	f.NavigationRules = append(rules, NavigationRule{When: expr, Target: target})
 	return f
 }
 
@@ -85,6 +239,74 @@ func (f Form) WithNavigation(handler NavigationHandler) Form {
 	return f
 }
 
+// WithNavigationTargets declares the forms the navigation handler may move to.
+// Declared targets are checked by ValidateNavigation and drawn in flow diagrams.
+func (f Form) WithNavigationTargets(formIDs ...string) Form {
+	f.NavigationTargets = formIDs
+	return f
+}
+
+// WithReads declares the global keys the form reads.
+// Reads are checked against earlier writes and shown as the reason when the form is skipped.
+func (f Form) WithReads(keys ...string) Form {
+	f.Reads = keys
+	return f
+}
+
+// WithWrites declares the global keys the form writes.
+// Writes that no form reads are reported when the flow is built.
+func (f Form) WithWrites(keys ...string) Form {
+	f.Writes = keys
+	return f
+}
+
+// declaredReads returns the keys the form declares with WithReads together with
+// the keys read by its skip expression and navigation rules.
+func (f Form) declaredReads() []string {
+	reads := appendUnique(nil, f.Reads...)
+	if f.SkipWhen != nil {
+		reads = appendUnique(reads, f.SkipWhen.Keys()...)
+	}
+	for _, rule := range f.NavigationRules {
+		reads = appendUnique(reads, rule.When.Keys()...)
+	}
+	return reads
+}
+
+// declaredTargets returns the declared navigation targets together with the targets of the navigation rules.
+func (f Form) declaredTargets() []string {
+	targets := appendUnique(nil, f.NavigationTargets...)
+	for _, rule := range f.NavigationRules {
+		targets = appendUnique(targets, rule.Target)
+	}
+	return targets
+}
+
+// appendUnique appends the values not already in the slice.
+func appendUnique(slice []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range slice {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
+
+// WithSensitive marks the form as asking for secrets, such as passwords.
+// Session recordings replace the characters typed into it with '*', and the keys
+// it writes are not saved to the previous answers file.
+func (f Form) WithSensitive() Form {
+	f.Sensitive = true
+	return f
+}
+
 // WithoutStatus disables the progress status display for this form.
 // The form will not show progress information in the UI.
 func (f Form) WithoutStatus() Form {
@@ -0,0 +1,90 @@
+package bobarista
+
+import "fmt"
+
+// formDataFor returns the stored data for the given form.
+// The returned values share storage with the flow, so hooks can modify them.
+func (f *Bobarista) formDataFor(form *Form) *FormData {
+	if f.formValues == nil {
+		f.formValues = make(map[string]FormValues)
+	}
+	if _, exists := f.formValues[form.ID]; !exists {
+		f.formValues[form.ID] = *NewFormValues()
+	}
+	values := f.formValues[form.ID]
+	return &FormData{
+		ID:     form.ID,
+		Values: &values,
+	}
+}
+
+// runEnterHooks calls the form's OnEnter handler followed by the flow's OnFormEnter hook.
+func (f *Bobarista) runEnterHooks(form *Form, current *FormData) error {
+	if form.OnEnter != nil {
+		f.debugLog(fmt.Sprintf("Calling OnEnter for form '%s'", form.ID))
+		if err := form.OnEnter(current, f.globalData); err != nil {
+			return err
+		}
+	}
+	if f.config.OnFormEnter != nil {
+		f.debugLog(fmt.Sprintf("Calling OnFormEnter for form '%s'", form.ID))
+		if err := f.config.OnFormEnter(f, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runLeaveHooks calls the form's OnLeave handler followed by the flow's OnFormLeave hook.
+func (f *Bobarista) runLeaveHooks(form *Form, current *FormData) error {
+	if form.OnLeave != nil {
+		f.debugLog(fmt.Sprintf("Calling OnLeave for form '%s'", form.ID))
+		if err := form.OnLeave(current, f.globalData); err != nil {
+			return err
+		}
+	}
+	if f.config.OnFormLeave != nil {
+		f.debugLog(fmt.Sprintf("Calling OnFormLeave for form '%s'", form.ID))
+		if err := f.config.OnFormLeave(f, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runSkipHooks calls the form's OnSkip handler followed by the flow's OnFormSkip hook.
+// Values the form contributed to global data earlier are retracted before the hooks run.
+func (f *Bobarista) runSkipHooks(form *Form) error {
+	f.recordSkipReason(form)
+	f.retractValues(form.ID)
+	current := f.formDataFor(form)
+	if form.OnSkip != nil {
+		f.debugLog(fmt.Sprintf("Calling OnSkip for form '%s'", form.ID))
+		if err := form.OnSkip(current, f.globalData); err != nil {
+			return err
+		}
+	}
+	if f.config.OnFormSkip != nil {
+		f.debugLog(fmt.Sprintf("Calling OnFormSkip for form '%s'", form.ID))
+		if err := f.config.OnFormSkip(f, current); err != nil {
+			return err
+		}
+	}
+	f.emit(Event{Type: EventFormSkipped, FormID: form.ID})
+	return nil
+}
+
+// notifySkipped runs the skip hooks for every form the navigator skipped during
+// its most recent search. It stops at the first hook error and records it.
+func (f *Bobarista) notifySkipped() bool {
+	for _, idx := range f.navigator.LastSkipped() {
+		form := &f.navigator.forms[idx]
+		f.infoLog(fmt.Sprintf("Form '%s' skipped by navigation", form.ID))
+		if err := f.runSkipHooks(form); err != nil {
+			f.errorLog(fmt.Errorf("OnSkip error for form '%s': %w", form.ID, err))
+			f.addError(form.ID, err)
+			return false
+		}
+	}
+	return true
+}
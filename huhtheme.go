@@ -0,0 +1,53 @@
+package bobarista
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HuhTheme creates a huh.Theme that matches the color scheme.
+// Bobarista applies it to every generated form unless WithoutFormTheme is used.
+func (c ColorScheme) HuhTheme() *huh.Theme {
+	t := huh.ThemeBase()
+
+	var (
+		normalFg = lipgloss.AdaptiveColor{Light: "235", Dark: "252"}
+		subtleFg = lipgloss.AdaptiveColor{Light: "", Dark: "243"}
+		buttonFg = lipgloss.AdaptiveColor{Light: "#FFFDF5", Dark: "#FFFDF5"}
+	)
+
+	t.Focused.Base = t.Focused.Base.BorderForeground(c.Tertiary)
+	t.Focused.Card = t.Focused.Base
+	t.Focused.Title = t.Focused.Title.Foreground(c.Primary).Bold(true)
+	t.Focused.NoteTitle = t.Focused.NoteTitle.Foreground(c.Primary).Bold(true).MarginBottom(1)
+	t.Focused.Directory = t.Focused.Directory.Foreground(c.Primary)
+	t.Focused.Description = t.Focused.Description.Foreground(subtleFg)
+	t.Focused.ErrorIndicator = t.Focused.ErrorIndicator.Foreground(c.Error)
+	t.Focused.ErrorMessage = t.Focused.ErrorMessage.Foreground(c.Error)
+	t.Focused.SelectSelector = t.Focused.SelectSelector.Foreground(c.Secondary)
+	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(c.Secondary)
+	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(c.Secondary)
+	t.Focused.Option = t.Focused.Option.Foreground(normalFg)
+	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(c.Secondary)
+	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(c.Success)
+	t.Focused.SelectedPrefix = lipgloss.NewStyle().Foreground(c.Success).SetString("✓ ")
+	t.Focused.UnselectedPrefix = lipgloss.NewStyle().Foreground(subtleFg).SetString("• ")
+	t.Focused.UnselectedOption = t.Focused.UnselectedOption.Foreground(normalFg)
+	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(buttonFg).Background(c.Primary)
+	t.Focused.Next = t.Focused.FocusedButton
+	t.Focused.BlurredButton = t.Focused.BlurredButton.Foreground(normalFg).Background(lipgloss.AdaptiveColor{Light: "252", Dark: "237"})
+
+	t.Focused.TextInput.Cursor = t.Focused.TextInput.Cursor.Foreground(c.Secondary)
+	t.Focused.TextInput.Placeholder = t.Focused.TextInput.Placeholder.Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"})
+	t.Focused.TextInput.Prompt = t.Focused.TextInput.Prompt.Foreground(c.Secondary)
+
+	t.Blurred = t.Focused
+	t.Blurred.Base = t.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
+	t.Blurred.Card = t.Blurred.Base
+	t.Blurred.NextIndicator = lipgloss.NewStyle()
+	t.Blurred.PrevIndicator = lipgloss.NewStyle()
+
+	t.Group.Title = t.Focused.Title
+	t.Group.Description = t.Focused.Description
+	return t
+}
@@ -0,0 +1,328 @@
+package bobarista
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inspectorTab identifies a page of the debug inspector.
+type inspectorTab int
+
+const (
+	inspectValues inspectorTab = iota
+	inspectForms
+	inspectHistory
+	inspectEvents
+	inspectorTabCount
+)
+
+// inspector holds the state of the interactive debug inspector.
+// It replaces the static debug panel while open.
+type inspector struct {
+	open    bool
+	tab     inspectorTab
+	cursor  int
+	editing bool
+	editKey string
+	input   textinput.Model
+	skips   map[string]bool
+}
+
+// newInspector creates a closed inspector.
+func newInspector() *inspector {
+	input := textinput.New()
+	input.Prompt = "= "
+	return &inspector{input: input}
+}
+
+// IsInspecting returns true if the debug inspector is open.
+func (f *Bobarista) IsInspecting() bool {
+	return f.inspector != nil && f.inspector.open
+}
+
+// handleInspectorKey processes key presses for the debug inspector.
+// The second result is false when the key was not handled and should be processed normally.
+func (f *Bobarista) handleInspectorKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	in := f.inspector
+	keys := f.keys.Inspector
+
+	if !in.open {
+		if key.Matches(msg, keys.Toggle) {
+			f.debugLog("Opening inspector")
+			in.open = true
+			in.cursor = 0
+			return nil, true
+		}
+		return nil, false
+	}
+
+	if in.editing {
+		switch {
+		case key.Matches(msg, keys.Select):
+			f.infoLog(fmt.Sprintf("Inspector set '%s' to '%s'", in.editKey, in.input.Value()))
+			f.globalData.Values.Set(in.editKey, in.input.Value())
+			f.updateDerived()
+			in.editing = false
+			in.input.Blur()
+		case key.Matches(msg, keys.Cancel):
+			in.editing = false
+			in.input.Blur()
+		case key.Matches(msg, f.keys.Quit):
+			return nil, false
+		default:
+			var cmd tea.Cmd
+			in.input, cmd = in.input.Update(msg)
+			return cmd, true
+		}
+		return nil, true
+	}
+
+	switch {
+	case key.Matches(msg, keys.Toggle), key.Matches(msg, keys.Cancel):
+		f.debugLog("Closing inspector")
+		in.open = false
+	case key.Matches(msg, keys.NextTab):
+		in.tab = (in.tab + 1) % inspectorTabCount
+		in.cursor = 0
+	case key.Matches(msg, keys.PrevTab):
+		in.tab = (in.tab + inspectorTabCount - 1) % inspectorTabCount
+		in.cursor = 0
+	case key.Matches(msg, keys.Up):
+		if in.cursor > 0 {
+			in.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if in.cursor < f.inspectorRowCount()-1 {
+			in.cursor++
+		}
+	case key.Matches(msg, keys.Rerun):
+		f.debugLog("Inspector re-running skip conditions")
+		in.skips = f.evaluateSkipConditions()
+	case key.Matches(msg, keys.Select):
+		return f.inspectorSelect(), true
+	case key.Matches(msg, f.keys.Quit):
+		return nil, false
+	}
+	return nil, true
+}
+
+// inspectorSelect edits the selected global value or jumps to the selected form.
+func (f *Bobarista) inspectorSelect() tea.Cmd {
+	in := f.inspector
+	switch in.tab {
+	case inspectValues:
+		keys := sortedKeys(*f.globalData.Values)
+		if in.cursor >= len(keys) {
+			return nil
+		}
+		if f.IsDerived(keys[in.cursor]) {
+			f.debugLog(fmt.Sprintf("Inspector cannot edit derived value '%s'", keys[in.cursor]))
+			return nil
+		}
+		value, _ := f.globalData.Values.Get(keys[in.cursor])
+		in.editing = true
+		in.editKey = keys[in.cursor]
+		in.input.SetValue(value)
+		in.input.CursorEnd()
+		return in.input.Focus()
+	case inspectForms:
+		if in.cursor >= len(f.forms) {
+			return nil
+		}
+		cmd, err := f.jumpTo(f.forms[in.cursor].ID)
+		if err != nil {
+			f.errorLog(fmt.Errorf("inspector jump failed: %w", err))
+			return nil
+		}
+		return cmd
+	}
+	return nil
+}
+
+// inspectorRowCount returns the number of selectable rows on the current tab.
+func (f *Bobarista) inspectorRowCount() int {
+	switch f.inspector.tab {
+	case inspectValues:
+		return len(*f.globalData.Values)
+	case inspectForms:
+		return len(f.forms)
+	case inspectHistory:
+		return len(f.navigator.GetHistory()) + 1
+	default:
+		return len(f.eventLog)
+	}
+}
+
+// renderInspector renders the debug inspector in place of the debug panel.
+func (r *Renderer) renderInspector(cupSleeve *Bobarista, width, height int) string {
+	in := cupSleeve.inspector
+
+	var content strings.Builder
+	content.WriteString(r.styles.Highlight.Render(r.text(MsgInspector)))
+	content.WriteString("\n\n")
+
+	tabs := []MessageKey{MsgInspectorValues, MsgInspectorForms, MsgInspectorHistory, MsgInspectorEvents}
+	for i, tab := range tabs {
+		if i > 0 {
+			content.WriteString(" ")
+		}
+		if inspectorTab(i) == in.tab {
+			content.WriteString(r.styles.Highlight.Render("[" + r.text(tab) + "]"))
+		} else {
+			content.WriteString(r.styles.Help.Render(" " + r.text(tab) + " "))
+		}
+	}
+	content.WriteString("\n\n")
+
+	var rows []string
+	switch in.tab {
+	case inspectValues:
+		rows = r.inspectorValueRows(cupSleeve)
+	case inspectForms:
+		rows = r.inspectorFormRows(cupSleeve)
+	case inspectHistory:
+		rows = r.inspectorHistoryRows(cupSleeve)
+	default:
+		rows = r.inspectorEventRows(cupSleeve)
+	}
+
+	if len(rows) == 0 {
+		content.WriteString("  " + r.styles.Help.Render(r.text(MsgEmpty)) + "\n")
+	}
+
+	visible := r.height - 12
+	if r.isNarrow() || visible < 3 {
+		visible = 3
+	}
+	start := 0
+	if in.cursor >= visible {
+		start = in.cursor - visible + 1
+	}
+	for i := start; i < len(rows) && i < start+visible; i++ {
+		if i == in.cursor {
+			content.WriteString(r.styles.Highlight.Render("> ") + rows[i] + "\n")
+		} else {
+			content.WriteString("  " + rows[i] + "\n")
+		}
+	}
+
+	if in.editing {
+		content.WriteString("\n" + r.styles.KeyText.Render(in.editKey) + "\n")
+		content.WriteString(in.input.View() + "\n")
+	}
+
+	if in.tab == inspectForms && in.cursor < len(cupSleeve.forms) {
+		content.WriteString("\n" + r.inspectorFormDetail(cupSleeve, cupSleeve.forms[in.cursor].ID))
+	}
+
+	return r.renderDebugBox(content.String(), width, height)
+}
+
+// inspectorValueRows lists the global values, marking derived values as read-only.
+func (r *Renderer) inspectorValueRows(cupSleeve *Bobarista) []string {
+	values := *cupSleeve.globalData.Values
+	var rows []string
+	for _, key := range sortedKeys(values) {
+		row := fmt.Sprintf("%s: %s",
+			r.styles.KeyText.Render(key),
+			r.styles.ValueText.Render(r.debugValue(values[key])))
+		if cupSleeve.IsDerived(key) {
+			row += " " + r.styles.Help.Render("("+r.text(MsgInspectorDerived)+")")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// inspectorFormRows lists the forms with their skip results and the current form marked.
+func (r *Renderer) inspectorFormRows(cupSleeve *Bobarista) []string {
+	current := cupSleeve.navigator.Current()
+	var rows []string
+	for _, form := range cupSleeve.forms {
+		row := r.styles.KeyText.Render(form.ID) + " " + form.Name
+		if skip, evaluated := cupSleeve.inspector.skips[form.ID]; evaluated {
+			if skip {
+				row += " " + r.styles.Warning.Render(r.text(MsgInspectorSkip))
+			} else {
+				row += " " + r.styles.Success.Render(r.text(MsgInspectorShow))
+			}
+		}
+		if current != nil && current.ID == form.ID {
+			row += " " + r.styles.Info.Render("("+r.text(MsgInspectorCurrent)+")")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// inspectorFormDetail lists the per-form values and owned global keys of a form.
+func (r *Renderer) inspectorFormDetail(cupSleeve *Bobarista, formID string) string {
+	var content strings.Builder
+	content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugFormValues)) + "\n")
+
+	values := cupSleeve.formValues[formID]
+	if len(values) == 0 {
+		content.WriteString("  " + r.styles.Help.Render(r.text(MsgEmpty)) + "\n")
+	}
+	for _, key := range sortedKeys(values) {
+		content.WriteString(fmt.Sprintf("  %s: %s\n",
+			r.styles.KeyText.Render(key),
+			r.styles.ValueText.Render(r.debugValue(values[key]))))
+	}
+
+	if owned := cupSleeve.GetOwnedKeys(formID); len(owned) > 0 {
+		content.WriteString(fmt.Sprintf("%s %s\n",
+			r.styles.KeyText.Render(r.text(MsgInspectorOwns)),
+			r.styles.ValueText.Render(strings.Join(owned, ", "))))
+	}
+	return content.String()
+}
+
+// inspectorHistoryRows lists the visited forms, oldest first, ending with the current form.
+func (r *Renderer) inspectorHistoryRows(cupSleeve *Bobarista) []string {
+	var rows []string
+	for i, index := range cupSleeve.navigator.GetHistory() {
+		rows = append(rows, fmt.Sprintf("%d. %s", i+1, cupSleeve.forms[index].ID))
+	}
+	if current := cupSleeve.navigator.Current(); current != nil {
+		rows = append(rows, fmt.Sprintf("%d. %s %s", len(rows)+1,
+			r.styles.KeyText.Render(current.ID),
+			r.styles.Info.Render("("+r.text(MsgInspectorCurrent)+")")))
+	}
+	return rows
+}
+
+// inspectorEventRows lists the event log, oldest first.
+func (r *Renderer) inspectorEventRows(cupSleeve *Bobarista) []string {
+	var rows []string
+	for _, event := range cupSleeve.eventLog {
+		row := r.styles.Help.Render(event.Time.Format("15:04:05.000")) + " " + event.Type.String()
+		if event.FormID != "" {
+			row += " " + r.styles.KeyText.Render(event.FormID)
+		}
+		if event.Type == EventNavigationDecided {
+			row += " → " + r.styles.KeyText.Render(event.NextFormID)
+		}
+		if event.Err != nil {
+			row += " " + r.styles.Error.Render(event.Err.Error())
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// debugValue formats a value pointer for the debug panel and inspector.
+func (r *Renderer) debugValue(value *string) string {
+	switch {
+	case value == nil:
+		return r.text(MsgNil)
+	case *value == "":
+		return r.text(MsgEmpty)
+	default:
+		return *value
+	}
+}
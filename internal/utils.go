@@ -2,10 +2,15 @@
 // These are implementation details and should not be used directly by external code.
 package internal
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
 
 // WrapText wraps the given text to fit within the specified width.
 // It breaks text at word boundaries and returns a slice of lines.
+// Widths are measured in terminal cells, ignoring ANSI escape sequences.
 // If width is 0 or negative, returns the original text as a single line.
 func WrapText(text string, width int) []string {
 	if width <= 0 {
@@ -19,19 +24,24 @@ func WrapText(text string, width int) []string {
 
 	var lines []string
 	var currentLine strings.Builder
+	lineWidth := 0
 
 	for _, word := range words {
+		wordWidth := ansi.StringWidth(word)
 		if currentLine.Len() == 0 {
 			// First word on the line
 			currentLine.WriteString(word)
-		} else if currentLine.Len()+1+len(word) <= width {
+			lineWidth = wordWidth
+		} else if lineWidth+1+wordWidth <= width {
 			// Word fits on current line with a space
 			currentLine.WriteString(" " + word)
+			lineWidth += 1 + wordWidth
 		} else {
 			// Word doesn't fit, start a new line
 			lines = append(lines, currentLine.String())
 			currentLine.Reset()
 			currentLine.WriteString(word)
+			lineWidth = wordWidth
 		}
 	}
 
@@ -0,0 +1,168 @@
+package bobarista
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines the key bindings handled by Bobarista itself.
+// Keys not matched here are passed on to the current huh form.
+// Help descriptions left empty are taken from the locale's message catalog.
+type KeyMap struct {
+	// Quit aborts the flow while a form is active.
+	Quit key.Binding
+
+	// ConfirmQuit and CancelQuit answer the quit confirmation prompt.
+	ConfirmQuit key.Binding
+	CancelQuit  key.Binding
+
+	// ScrollUp and ScrollDown scroll the completion screen.
+	ScrollUp   key.Binding
+	ScrollDown key.Binding
+
+	// Finish runs the completion callback and exits from the completion screen.
+	Finish key.Binding
+
+	// Close exits from the completion or error screen without finishing.
+	Close key.Binding
+
+	// Inspector holds the bindings of the debug inspector.
+	Inspector InspectorKeyMap
+
+	// Launcher holds the bindings of the Launcher menu.
+	Launcher LauncherKeyMap
+}
+
+// InspectorKeyMap defines the key bindings of the debug inspector.
+// While the inspector is open it receives all keys instead of the current form.
+type InspectorKeyMap struct {
+	// Toggle opens and closes the inspector in debug mode.
+	Toggle key.Binding
+
+	// NextTab and PrevTab switch between the inspector tabs.
+	NextTab key.Binding
+	PrevTab key.Binding
+
+	// Up and Down move the selection.
+	Up   key.Binding
+	Down key.Binding
+
+	// Select edits the selected value, or jumps to the selected form.
+	// While editing, it saves the new value.
+	Select key.Binding
+
+	// Rerun evaluates the skip conditions of all forms against the current data.
+	Rerun key.Binding
+
+	// Cancel stops editing, or closes the inspector.
+	Cancel key.Binding
+}
+
+// LauncherKeyMap defines the key bindings of the Launcher menu.
+// The Quit and Close bindings leave the menu.
+type LauncherKeyMap struct {
+	// Up and Down move the selection.
+	Up   key.Binding
+	Down key.Binding
+
+	// Select starts the selected flow.
+	Select key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", ""),
+		),
+		ConfirmQuit: key.NewBinding(
+			key.WithKeys("y", "Y"),
+			key.WithHelp("y", ""),
+		),
+		CancelQuit: key.NewBinding(
+			key.WithKeys("n", "N", "esc"),
+			key.WithHelp("n", ""),
+		),
+		ScrollUp: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", ""),
+		),
+		ScrollDown: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", ""),
+		),
+		Finish: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", ""),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q", ""),
+		),
+		Inspector: InspectorKeyMap{
+			Toggle: key.NewBinding(
+				key.WithKeys("f2"),
+				key.WithHelp("f2", ""),
+			),
+			NextTab: key.NewBinding(
+				key.WithKeys("tab", "right"),
+				key.WithHelp("tab", ""),
+			),
+			PrevTab: key.NewBinding(
+				key.WithKeys("shift+tab", "left"),
+				key.WithHelp("shift+tab", ""),
+			),
+			Up: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑", ""),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓", ""),
+			),
+			Select: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", ""),
+			),
+			Rerun: key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", ""),
+			),
+			Cancel: key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", ""),
+			),
+		},
+		Launcher: LauncherKeyMap{
+			Up: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑", ""),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓", ""),
+			),
+			Select: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", ""),
+			),
+		},
+	}
+}
+
+// withHelp returns a copy of binding whose empty help description is replaced by desc.
+func withHelp(binding key.Binding, desc string) key.Binding {
+	help := binding.Help()
+	if help.Desc == "" {
+		binding.SetHelp(help.Key, desc)
+	}
+	return binding
+}
+
+// scrollBinding combines the scroll bindings into a single help entry.
+func (k KeyMap) scrollBinding() key.Binding {
+	return key.NewBinding(
+		key.WithKeys(append(k.ScrollUp.Keys(), k.ScrollDown.Keys()...)...),
+		key.WithHelp(k.ScrollUp.Help().Key+"/"+k.ScrollDown.Help().Key, k.ScrollUp.Help().Desc),
+	)
+}
@@ -0,0 +1,349 @@
+package bobarista
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LauncherEntry is a named flow offered by a Launcher.
+type LauncherEntry struct {
+	// Name is shown in the menu and in the status line after the flow exits.
+	Name string
+
+	// Description is shown below the name in the menu.
+	Description string
+
+	// Flow builds the flow each time it is started, so every run begins with fresh values.
+	Flow *BobaBuilder
+}
+
+// Launcher shows a menu of named flows, runs the chosen one, and returns to the menu
+// when it exits. Theme, key bindings, locale and logger are shared with every flow.
+type Launcher struct {
+	config   Recipe
+	entries  []LauncherEntry
+	renderer *Renderer
+	keys     KeyMap
+	selected int
+
+	program    *tea.Program
+	onLaunch   func(name string, controller *Controller)
+	active     *Bobarista
+	activeName string
+	controller *Controller
+	runSeq     int
+	width      int
+	height     int
+
+	lastName     string
+	lastFinished bool
+}
+
+// flowExitedMsg is sent when the flow started by a Launcher exits.
+type flowExitedMsg struct {
+	seq int
+}
+
+// NewLauncher creates a Launcher with the specified menu title.
+func NewLauncher(title string) *Launcher {
+	return &Launcher{
+		config: Recipe{
+			Title:    title,
+			MaxWidth: 180,
+		},
+	}
+}
+
+// AddFlow adds a flow to the menu. The builder is built again each time the flow is started.
+func (l *Launcher) AddFlow(name, description string, flow *BobaBuilder) *Launcher {
+	l.entries = append(l.entries, LauncherEntry{Name: name, Description: description, Flow: flow})
+	return l
+}
+
+// WithColorScheme sets the color scheme of the menu and every flow.
+func (l *Launcher) WithColorScheme(scheme string) *Launcher {
+	l.config.ColorScheme = scheme
+	return l
+}
+
+// WithStyleOverride customizes individual style elements of the menu and every flow.
+func (l *Launcher) WithStyleOverride(override StyleOverride) *Launcher {
+	l.config.StyleOverrides = append(l.config.StyleOverrides, override)
+	return l
+}
+
+// WithKeyMap replaces the default key bindings of the menu and every flow.
+func (l *Launcher) WithKeyMap(keys KeyMap) *Launcher {
+	l.config.KeyMap = &keys
+	return l
+}
+
+// WithLocale sets the locale of the menu and every flow.
+func (l *Launcher) WithLocale(locale string) *Launcher {
+	l.config.Locale = locale
+	return l
+}
+
+// WithLogger sets the logger used by the menu and every flow.
+func (l *Launcher) WithLogger(logger Logger) *Launcher {
+	l.config.Logger = logger
+	return l
+}
+
+// WithIO replaces standard input and output for the launcher.
+func (l *Launcher) WithIO(in io.Reader, out io.Writer) *Launcher {
+	l.config.Input = in
+	l.config.Output = out
+	return l
+}
+
+// OnLaunch sets a callback that is called each time a flow is started, with a
+// Controller for that run. The Controller can be passed to other goroutines to
+// drive the flow; its Wait returns when the flow exits back to the menu.
+func (l *Launcher) OnLaunch(handler func(name string, controller *Controller)) *Launcher {
+	l.onLaunch = handler
+	return l
+}
+
+// Run shows the menu and blocks until the user leaves it.
+// It fails with ErrAccessibleLauncher if a flow was built with WithAccessible.
+func (l *Launcher) Run() error {
+	for _, entry := range l.entries {
+		if entry.Flow.config.Accessible {
+			return fmt.Errorf("%w: '%s'", ErrAccessibleLauncher, entry.Name)
+		}
+	}
+
+	l.infoLog("Starting Bobarista launcher")
+	l.program = tea.NewProgram(l, programOptions(l.config)...)
+	_, err := l.program.Run()
+	if l.active != nil {
+		l.returnToMenu()
+	}
+	l.program = nil
+	if err != nil {
+		l.errorLog(fmt.Errorf("tea program error: %w", err))
+	}
+	return err
+}
+
+// Init implements the tea.Model interface and prepares the menu.
+func (l *Launcher) Init() tea.Cmd {
+	if l.config.ColorScheme == "" {
+		l.config.ColorScheme = "default"
+	}
+	l.keys = DefaultKeyMap()
+	if l.config.KeyMap != nil {
+		l.keys = *l.config.KeyMap
+	}
+	l.renderer = NewRenderer(l.config)
+	if l.width > 0 {
+		l.renderer.UpdateSize(l.width, l.height)
+	}
+	return nil
+}
+
+// Update implements the tea.Model interface. While a flow runs, messages are passed on to it.
+func (l *Launcher) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		l.width, l.height = msg.Width, msg.Height
+		l.renderer.UpdateSize(msg.Width, msg.Height)
+	case flowExitedMsg:
+		if l.active != nil && msg.seq == l.runSeq {
+			l.returnToMenu()
+		}
+		return l, nil
+	}
+
+	if l.active != nil {
+		_, cmd := l.active.Update(msg)
+		return l, cmd
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		return l.handleMenuKey(msg)
+	}
+	return l, nil
+}
+
+// View implements the tea.Model interface, rendering the running flow or the menu.
+func (l *Launcher) View() string {
+	if l.active != nil {
+		return l.active.View()
+	}
+	return l.renderer.renderLauncher(l)
+}
+
+// GetActiveFlow returns the running flow, or nil while the menu is shown.
+func (l *Launcher) GetActiveFlow() *Bobarista {
+	return l.active
+}
+
+// GetSelected returns the index of the selected menu entry.
+func (l *Launcher) GetSelected() int {
+	return l.selected
+}
+
+// handleMenuKey processes input while the menu is shown.
+func (l *Launcher) handleMenuKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := l.keys.Launcher
+	switch {
+	case key.Matches(msg, l.keys.Quit), key.Matches(msg, l.keys.Close):
+		l.infoLog("User left the launcher")
+		return l, tea.Quit
+	case key.Matches(msg, keys.Up):
+		if l.selected > 0 {
+			l.selected--
+		}
+	case key.Matches(msg, keys.Down):
+		if l.selected < len(l.entries)-1 {
+			l.selected++
+		}
+	case key.Matches(msg, keys.Select):
+		if len(l.entries) > 0 {
+			return l, l.launch(l.entries[l.selected])
+		}
+	}
+	return l, nil
+}
+
+// launch builds the entry's flow with the shared settings and starts it.
+// The flow's exit is turned into a flowExitedMsg so the menu can take over again.
+// Each run gets its own ID, so delayed messages of an earlier run are ignored.
+func (l *Launcher) launch(entry LauncherEntry) tea.Cmd {
+	l.infoLog(fmt.Sprintf("Launching flow '%s'", entry.Name))
+
+	builder := *entry.Flow
+	builder.config = l.shared(builder.config)
+	flow := builder.Build()
+
+	l.runSeq++
+	seq := l.runSeq
+	flow.runID = seq
+	flow.onExit = func() tea.Msg {
+		return flowExitedMsg{seq: seq}
+	}
+	if l.program != nil {
+		flow.setProgram(l.program)
+	}
+	l.active = flow
+	l.activeName = entry.Name
+	l.controller = &Controller{flow: flow, done: make(chan struct{})}
+	if l.onLaunch != nil {
+		l.onLaunch(entry.Name, l.controller)
+	}
+
+	cmds := []tea.Cmd{flow.Init()}
+	if l.width > 0 {
+		_, cmd := flow.Update(tea.WindowSizeMsg{Width: l.width, Height: l.height})
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// shared applies the launcher's settings to a flow's configuration.
+// Settings the launcher leaves unset keep the flow's own values.
+func (l *Launcher) shared(config Recipe) Recipe {
+	if l.config.ColorScheme != "" {
+		config.ColorScheme = l.config.ColorScheme
+	}
+	if len(l.config.StyleOverrides) > 0 {
+		overrides := append([]StyleOverride(nil), config.StyleOverrides...)
+		config.StyleOverrides = append(overrides, l.config.StyleOverrides...)
+	}
+	if l.config.KeyMap != nil {
+		config.KeyMap = l.config.KeyMap
+	}
+	if l.config.Locale != "" {
+		config.Locale = l.config.Locale
+	}
+	if l.config.Logger != nil {
+		config.Logger = l.config.Logger
+	}
+	return config
+}
+
+// returnToMenu records the outcome of the running flow, closes its recording,
+// releases its Controller, and shows the menu again.
+func (l *Launcher) returnToMenu() {
+	l.active.setProgram(nil)
+	l.active.stopRecording()
+	l.controller.result = l.active.result(nil)
+	close(l.controller.done)
+
+	l.lastName = l.activeName
+	l.lastFinished = l.active.finished
+	l.infoLog(fmt.Sprintf("Flow '%s' exited, finished: %t", l.activeName, l.lastFinished))
+	l.active = nil
+	l.activeName = ""
+	l.controller = nil
+}
+
+// infoLog logs an informational message to the configured logger.
+func (l *Launcher) infoLog(message string) {
+	if l.config.Logger != nil {
+		l.config.Logger.LogInfo(message)
+	}
+}
+
+// errorLog logs an error message to the configured logger.
+func (l *Launcher) errorLog(err error) {
+	if l.config.Logger != nil {
+		l.config.Logger.LogError(err)
+	}
+}
+
+// renderLauncher renders the menu of flows with the outcome of the last run.
+func (r *Renderer) renderLauncher(l *Launcher) string {
+	header := r.renderHeader(l.config.Title)
+
+	var body strings.Builder
+	if len(l.entries) == 0 {
+		body.WriteString(r.text(MsgNoFlows))
+	}
+	for i, entry := range l.entries {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		if i == l.selected {
+			body.WriteString(r.styles.Highlight.Render("> " + entry.Name))
+		} else {
+			body.WriteString("  " + entry.Name)
+		}
+		if entry.Description != "" {
+			body.WriteString("\n    " + r.styles.Help.Render(entry.Description))
+		}
+	}
+
+	if l.lastName != "" {
+		body.WriteString("\n\n")
+		if l.lastFinished {
+			body.WriteString(r.styles.Success.Render(r.text(MsgLauncherCompleted, l.lastName)))
+		} else {
+			body.WriteString(r.styles.Warning.Render(r.text(MsgLauncherAborted, l.lastName)))
+		}
+	}
+
+	r.help.Width = r.width
+	keys := l.keys
+	footer := r.renderFooter(r.help.ShortHelpView([]key.Binding{
+		withHelp(keys.Launcher.moveBinding(), r.text(MsgHelpMove)),
+		withHelp(keys.Launcher.Select, r.text(MsgHelpStart)),
+		withHelp(keys.Quit, r.text(MsgHelpQuit)),
+	}), "")
+
+	return r.joinScreen(header, r.styles.Base.Render(body.String()), footer)
+}
+
+// moveBinding combines the up and down bindings into a single help entry.
+func (k LauncherKeyMap) moveBinding() key.Binding {
+	return key.NewBinding(
+		key.WithKeys(append(k.Up.Keys(), k.Down.Keys()...)...),
+		key.WithHelp(k.Up.Help().Key+"/"+k.Down.Help().Key, k.Up.Help().Desc),
+	)
+}
@@ -0,0 +1,107 @@
+package bobarista
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultLoadTimeout is the loader timeout used when a form does not specify one.
+const DefaultLoadTimeout = 30 * time.Second
+
+// formLoadedMsg is sent when a form loader has finished.
+// It carries either the loaded data or the error produced by the loader.
+type formLoadedMsg struct {
+	formID string
+	run    int
+	seq    int
+	data   any
+	err    error
+}
+
+// formLoadTimeoutMsg is sent when a form loader exceeds its timeout.
+type formLoadTimeoutMsg struct {
+	formID string
+	run    int
+	seq    int
+}
+
+// startLoader runs the loader of the given form and schedules its timeout.
+// Each load is tagged with the run ID and a sequence number so stale results are ignored.
+func (f *Bobarista) startLoader(form *Form, current *FormValues) tea.Cmd {
+	f.loadSeq++
+	f.loading = true
+	f.currentForm = nil
+
+	formID := form.ID
+	run, seq := f.runID, f.loadSeq
+
+	timeout := form.LoadTimeout
+	if timeout <= 0 {
+		timeout = DefaultLoadTimeout
+	}
+
+	f.infoLog(fmt.Sprintf("Loading data for form '%s' (timeout %s)", formID, timeout))
+
+	loadCmd := form.Loader(current, f.globalData.Values)
+	load := func() tea.Msg {
+		if loadCmd == nil {
+			return formLoadedMsg{formID: formID, run: run, seq: seq}
+		}
+		msg := loadCmd()
+		if err, ok := msg.(error); ok {
+			return formLoadedMsg{formID: formID, run: run, seq: seq, err: err}
+		}
+		return formLoadedMsg{formID: formID, run: run, seq: seq, data: msg}
+	}
+
+	expire := tea.Tick(timeout, func(time.Time) tea.Msg {
+		return formLoadTimeoutMsg{formID: formID, run: run, seq: seq}
+	})
+
+	return tea.Batch(load, expire)
+}
+
+// handleFormLoaded processes the result of a form loader.
+// It generates the form from the loaded data, or transitions to the error state.
+func (f *Bobarista) handleFormLoaded(msg formLoadedMsg) tea.Cmd {
+	if !f.loading || msg.run != f.runID || msg.seq != f.loadSeq {
+		f.debugLog(fmt.Sprintf("Ignoring stale load result for form '%s'", msg.formID))
+		return nil
+	}
+	f.loading = false
+
+	if msg.err != nil {
+		f.errorLog(fmt.Errorf("loader error for form '%s': %w", msg.formID, msg.err))
+		f.addError(msg.formID, msg.err)
+		return nil
+	}
+
+	current := f.navigator.Current()
+	if current == nil || current.ID != msg.formID {
+		f.warningLog(fmt.Sprintf("Load result for form '%s' arrived after navigation", msg.formID))
+		return nil
+	}
+
+	f.infoLog(fmt.Sprintf("Data loaded for form '%s'", current.ID))
+	currentValues := f.formValues[current.ID]
+	return f.generateForm(current, &currentValues, msg.data)
+}
+
+// handleFormLoadTimeout fails the flow if the matching load is still pending.
+func (f *Bobarista) handleFormLoadTimeout(msg formLoadTimeoutMsg) tea.Cmd {
+	if !f.loading || msg.run != f.runID || msg.seq != f.loadSeq {
+		return nil
+	}
+	f.loading = false
+
+	f.errorLog(fmt.Errorf("loader for form '%s' timed out", msg.formID))
+	f.addError(msg.formID, ErrLoadTimeout)
+	return nil
+}
+
+// IsLoading returns true while the current form's loader is running.
+func (f *Bobarista) IsLoading() bool {
+	return f.loading
+}
@@ -117,33 +117,49 @@ func LogWarning(message string) {
 	}
 }
 
-// debugLog logs a debug message if debug mode is enabled.
+// debugLog logs a debug message to the configured logger, or to the log file if debug mode is enabled.
 // This is a convenience method for conditional debug logging.
 func (f *Bobarista) debugLog(message string) {
+	if f.config.Logger != nil {
+		f.config.Logger.LogDebug(message)
+		return
+	}
 	if f.config.Debug {
 		LogDebug(message)
 	}
 }
 
-// infoLog logs an informational message if debug mode is enabled.
+// infoLog logs an informational message to the configured logger, or to the log file if debug mode is enabled.
 // This is a convenience method for conditional info logging.
 func (f *Bobarista) infoLog(message string) {
+	if f.config.Logger != nil {
+		f.config.Logger.LogInfo(message)
+		return
+	}
 	if f.config.Debug {
 		LogInfo(message)
 	}
 }
 
-// warningLog logs a warning message if debug mode is enabled.
+// warningLog logs a warning message to the configured logger, or to the log file if debug mode is enabled.
 // This is a convenience method for conditional warning logging.
 func (f *Bobarista) warningLog(message string) {
+	if f.config.Logger != nil {
+		f.config.Logger.LogWarning(message)
+		return
+	}
 	if f.config.Debug {
 		LogWarning(message)
 	}
 }
 
-// errorLog logs an error message if debug mode is enabled.
+// errorLog logs an error message to the configured logger, or to the log file if debug mode is enabled.
 // This is a convenience method for conditional error logging.
 func (f *Bobarista) errorLog(err error) {
+	if f.config.Logger != nil {
+		f.config.Logger.LogError(err)
+		return
+	}
 	if f.config.Debug {
 		LogError(err)
 	}
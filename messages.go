@@ -0,0 +1,612 @@
+package bobarista
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale used when none is set or the requested one is unknown.
+const DefaultLocale = "en"
+
+// AutoLocale selects the locale from the LC_ALL, LC_MESSAGES and LANG environment variables.
+const AutoLocale = "auto"
+
+// MessageKey identifies a framework-generated string in a message catalog.
+type MessageKey string
+
+// Messages is a message catalog mapping keys to translated strings.
+// Strings may contain fmt verbs for the values the renderer fills in.
+type Messages map[MessageKey]string
+
+// Message keys for all strings generated by the framework.
+const (
+	// MsgNoForms is shown when the flow has no forms.
+	MsgNoForms MessageKey = "no_forms"
+
+	// MsgActiveTitle is the header of the active form; takes the flow title, form name and progress percentage.
+	MsgActiveTitle MessageKey = "active_title"
+
+	// MsgQuitConfirm asks the user to confirm quitting.
+	MsgQuitConfirm MessageKey = "quit_confirm"
+
+	// MsgHelpQuit is the help text for the quit binding.
+	MsgHelpQuit MessageKey = "help_quit"
+
+	// MsgHelpScroll is the help text for the scroll bindings.
+	MsgHelpScroll MessageKey = "help_scroll"
+
+	// MsgHelpFinish is the help text for the finish binding on the completion screen.
+	MsgHelpFinish MessageKey = "help_finish"
+
+	// MsgHelpYes is the help text for confirming the quit prompt.
+	MsgHelpYes MessageKey = "help_yes"
+
+	// MsgHelpNo is the help text for dismissing the quit prompt.
+	MsgHelpNo MessageKey = "help_no"
+
+	// MsgHelpInspect is the help text for the inspector toggle.
+	MsgHelpInspect MessageKey = "help_inspect"
+
+	// MsgHelpTab is the help text for switching inspector tabs.
+	MsgHelpTab MessageKey = "help_tab"
+
+	// MsgHelpEdit is the help text for editing a value in the inspector.
+	MsgHelpEdit MessageKey = "help_edit"
+
+	// MsgHelpJump is the help text for jumping to a form from the inspector.
+	MsgHelpJump MessageKey = "help_jump"
+
+	// MsgHelpRerun is the help text for re-evaluating skip conditions.
+	MsgHelpRerun MessageKey = "help_rerun"
+
+	// MsgHelpSave is the help text for saving an edited value.
+	MsgHelpSave MessageKey = "help_save"
+
+	// MsgHelpCancel is the help text for cancelling an edit.
+	MsgHelpCancel MessageKey = "help_cancel"
+
+	// MsgHelpClose is the help text for closing the inspector.
+	MsgHelpClose MessageKey = "help_close"
+
+	// MsgInspector is the inspector title.
+	MsgInspector MessageKey = "inspector"
+
+	// MsgInspectorValues is the label of the inspector's values tab.
+	MsgInspectorValues MessageKey = "inspector_values"
+
+	// MsgInspectorForms is the label of the inspector's forms tab.
+	MsgInspectorForms MessageKey = "inspector_forms"
+
+	// MsgInspectorHistory is the label of the inspector's history tab.
+	MsgInspectorHistory MessageKey = "inspector_history"
+
+	// MsgInspectorEvents is the label of the inspector's events tab.
+	MsgInspectorEvents MessageKey = "inspector_events"
+
+	// MsgInspectorSkip marks a form that will be skipped.
+	MsgInspectorSkip MessageKey = "inspector_skip"
+
+	// MsgInspectorShow marks a form that will be shown.
+	MsgInspectorShow MessageKey = "inspector_show"
+
+	// MsgInspectorCurrent marks the current form.
+	MsgInspectorCurrent MessageKey = "inspector_current"
+
+	// MsgInspectorOwns labels the keys a form owns.
+	MsgInspectorOwns MessageKey = "inspector_owns"
+
+	// MsgInspectorDerived marks a derived value that cannot be edited.
+	MsgInspectorDerived MessageKey = "inspector_derived"
+
+	// MsgTimeRemaining is the countdown shown while a timeout runs; takes the remaining time.
+	MsgTimeRemaining MessageKey = "time_remaining"
+
+	// MsgNoFlows is shown when a launcher has no flows.
+	MsgNoFlows MessageKey = "no_flows"
+
+	// MsgHelpMove is the help text for moving through the launcher menu.
+	MsgHelpMove MessageKey = "help_move"
+
+	// MsgHelpStart is the help text for starting the selected flow.
+	MsgHelpStart MessageKey = "help_start"
+
+	// MsgLauncherCompleted reports that a launched flow completed; takes the flow name.
+	MsgLauncherCompleted MessageKey = "launcher_completed"
+
+	// MsgLauncherAborted reports that a launched flow exited early; takes the flow name.
+	MsgLauncherAborted MessageKey = "launcher_aborted"
+
+	// MsgDebugEnabled is shown when debug mode is on.
+	MsgDebugEnabled MessageKey = "debug_enabled"
+
+	// MsgTooSmall is shown when the terminal is below the minimum size; takes the current and required sizes.
+	MsgTooSmall MessageKey = "too_small"
+
+	// MsgLoadingForm is shown while an unnamed form loads.
+	MsgLoadingForm MessageKey = "loading_form"
+
+	// MsgLoadingNamed is shown while a named form loads; takes the form name.
+	MsgLoadingNamed MessageKey = "loading_named"
+
+	// MsgCompletedTitle is the header of the completion screen; takes the flow title.
+	MsgCompletedTitle MessageKey = "completed_title"
+
+	// MsgErrorTitle is the header of the error screen; takes the flow title.
+	MsgErrorTitle MessageKey = "error_title"
+
+	// MsgErrorsOccurred introduces the list of errors.
+	MsgErrorsOccurred MessageKey = "errors_occurred"
+
+	// MsgFormError is one entry of the error list; takes the form ID and error.
+	MsgFormError MessageKey = "form_error"
+
+	// MsgSummary is the heading of the completion summary.
+	MsgSummary MessageKey = "summary"
+
+	// MsgAllValues is the heading of the list of all values.
+	MsgAllValues MessageKey = "all_values"
+
+	// MsgNoValues is shown when there are no values to list.
+	MsgNoValues MessageKey = "no_values"
+
+	// MsgErrors prefixes the comma-separated list of errors.
+	MsgErrors MessageKey = "errors"
+
+	// MsgStep is the step line in accessible mode; takes the step number, form count and form name.
+	MsgStep MessageKey = "step"
+
+	// MsgDebugPanel is the debug panel title.
+	MsgDebugPanel MessageKey = "debug_panel"
+
+	// MsgDebugCurrentForm is the debug panel heading for the current form.
+	MsgDebugCurrentForm MessageKey = "debug_current_form"
+
+	// MsgDebugID labels the current form's ID in the debug panel.
+	MsgDebugID MessageKey = "debug_id"
+
+	// MsgDebugName labels the current form's name in the debug panel.
+	MsgDebugName MessageKey = "debug_name"
+
+	// MsgDebugIndex labels the current form's index in the debug panel.
+	MsgDebugIndex MessageKey = "debug_index"
+
+	// MsgDebugFormValues is the debug panel heading for the current form's values.
+	MsgDebugFormValues MessageKey = "debug_form_values"
+
+	// MsgDebugGlobalValues is the debug panel heading for the global values.
+	MsgDebugGlobalValues MessageKey = "debug_global_values"
+
+	// MsgDebugNavigation is the debug panel heading for navigation state.
+	MsgDebugNavigation MessageKey = "debug_navigation"
+
+	// MsgDebugHasPrevious labels whether a previous form exists.
+	MsgDebugHasPrevious MessageKey = "debug_has_previous"
+
+	// MsgDebugHasNext labels whether a next form exists.
+	MsgDebugHasNext MessageKey = "debug_has_next"
+
+	// MsgDebugProgress labels the progress percentage.
+	MsgDebugProgress MessageKey = "debug_progress"
+
+	// MsgDebugFormState is the debug panel heading for the form state.
+	MsgDebugFormState MessageKey = "debug_form_state"
+
+	// MsgDebugState labels the huh form state.
+	MsgDebugState MessageKey = "debug_state"
+
+	// MsgDebugErrors labels the error count.
+	MsgDebugErrors MessageKey = "debug_errors"
+
+	// MsgDebugSkipped is the debug panel heading for skipped forms.
+	MsgDebugSkipped MessageKey = "debug_skipped"
+
+	// MsgDebugSkipNoReads explains a skip whose condition declares no reads.
+	MsgDebugSkipNoReads MessageKey = "debug_skip_no_reads"
+
+	// MsgNoCurrentForm is shown when there is no current form.
+	MsgNoCurrentForm MessageKey = "no_current_form"
+
+	// MsgEmpty stands in for an empty value.
+	MsgEmpty MessageKey = "empty"
+
+	// MsgNil stands in for a missing value.
+	MsgNil MessageKey = "nil"
+
+	// MsgStateNormal names the huh state of a form being filled in.
+	MsgStateNormal MessageKey = "state_normal"
+
+	// MsgStateCompleted names the huh state of a completed form.
+	MsgStateCompleted MessageKey = "state_completed"
+
+	// MsgStateAborted names the huh state of an aborted form.
+	MsgStateAborted MessageKey = "state_aborted"
+
+	// MsgStateUnknown names an unrecognized huh state; takes the state number.
+	MsgStateUnknown MessageKey = "state_unknown"
+)
+
+// messageKeys lists every message key, in declaration order.
+// Keys added above must be added here too; the tests check each built-in catalog against it.
+var messageKeys = []MessageKey{
+	MsgNoForms,
+	MsgActiveTitle,
+	MsgQuitConfirm,
+	MsgHelpQuit,
+	MsgHelpScroll,
+	MsgHelpFinish,
+	MsgHelpYes,
+	MsgHelpNo,
+	MsgHelpInspect,
+	MsgHelpTab,
+	MsgHelpEdit,
+	MsgHelpJump,
+	MsgHelpRerun,
+	MsgHelpSave,
+	MsgHelpCancel,
+	MsgHelpClose,
+	MsgInspector,
+	MsgInspectorValues,
+	MsgInspectorForms,
+	MsgInspectorHistory,
+	MsgInspectorEvents,
+	MsgInspectorSkip,
+	MsgInspectorShow,
+	MsgInspectorCurrent,
+	MsgInspectorOwns,
+	MsgInspectorDerived,
+	MsgTimeRemaining,
+	MsgNoFlows,
+	MsgHelpMove,
+	MsgHelpStart,
+	MsgLauncherCompleted,
+	MsgLauncherAborted,
+	MsgDebugEnabled,
+	MsgTooSmall,
+	MsgLoadingForm,
+	MsgLoadingNamed,
+	MsgCompletedTitle,
+	MsgErrorTitle,
+	MsgErrorsOccurred,
+	MsgFormError,
+	MsgSummary,
+	MsgAllValues,
+	MsgNoValues,
+	MsgErrors,
+	MsgStep,
+	MsgDebugPanel,
+	MsgDebugCurrentForm,
+	MsgDebugID,
+	MsgDebugName,
+	MsgDebugIndex,
+	MsgDebugFormValues,
+	MsgDebugGlobalValues,
+	MsgDebugNavigation,
+	MsgDebugHasPrevious,
+	MsgDebugHasNext,
+	MsgDebugProgress,
+	MsgDebugFormState,
+	MsgDebugState,
+	MsgDebugErrors,
+	MsgDebugSkipped,
+	MsgDebugSkipNoReads,
+	MsgNoCurrentForm,
+	MsgEmpty,
+	MsgNil,
+	MsgStateNormal,
+	MsgStateCompleted,
+	MsgStateAborted,
+	MsgStateUnknown,
+}
+
+// MessageKeys returns every key a complete message catalog defines.
+// It can be used to check a catalog passed to RegisterLocale for missing translations.
+func MessageKeys() []MessageKey {
+	return slices.Clone(messageKeys)
+}
+
+// KeyLabel returns the message key for the display label of a form value key.
+// Catalogs and overrides can use it to translate the labels shown in summaries.
+func KeyLabel(key string) MessageKey {
+	return MessageKey("key." + key)
+}
+
+// localesMu guards locales, which RegisterLocale can change at any time.
+var localesMu sync.RWMutex
+
+// locales contains the registered message catalogs, keyed by locale name.
+var locales = map[string]Messages{
+	"en": {
+		MsgNoForms:           "No forms available",
+		MsgActiveTitle:       "%s - %s (%.0f%%)",
+		MsgQuitConfirm:       "Quit? Your answers will be lost.",
+		MsgHelpQuit:          "quit",
+		MsgHelpScroll:        "scroll",
+		MsgHelpFinish:        "finish",
+		MsgHelpYes:           "yes",
+		MsgHelpNo:            "no",
+		MsgHelpInspect:       "inspect",
+		MsgHelpTab:           "next tab",
+		MsgHelpEdit:          "edit",
+		MsgHelpJump:          "jump",
+		MsgHelpRerun:         "re-run skips",
+		MsgHelpSave:          "save",
+		MsgHelpCancel:        "cancel",
+		MsgHelpClose:         "close",
+		MsgInspector:         "🔍 INSPECTOR",
+		MsgInspectorValues:   "Values",
+		MsgInspectorForms:    "Forms",
+		MsgInspectorHistory:  "History",
+		MsgInspectorEvents:   "Events",
+		MsgInspectorSkip:     "skip",
+		MsgInspectorShow:     "show",
+		MsgInspectorCurrent:  "current",
+		MsgInspectorOwns:     "Owns:",
+		MsgInspectorDerived:  "derived, read-only",
+		MsgTimeRemaining:     "⏱ %s left",
+		MsgNoFlows:           "No flows available",
+		MsgHelpMove:          "move",
+		MsgHelpStart:         "start",
+		MsgLauncherCompleted: "✓ %s completed",
+		MsgLauncherAborted:   "%s was not completed",
+		MsgDebugEnabled:      "Debug mode enabled",
+		MsgTooSmall:          "Terminal too small\n%dx%d (need %dx%d)",
+		MsgLoadingForm:       "Loading form...",
+		MsgLoadingNamed:      "Loading %s...",
+		MsgCompletedTitle:    "%s - Completed",
+		MsgErrorTitle:        "%s - Error",
+		MsgErrorsOccurred:    "The following errors occurred:",
+		MsgFormError:         "Form '%s': %s",
+		MsgSummary:           "Summary:",
+		MsgAllValues:         "All Values:",
+		MsgNoValues:          "No values to display.",
+		MsgErrors:            "Errors: ",
+		MsgStep:              "Step %d of %d: %s",
+		MsgDebugPanel:        "🐛 DEBUG PANEL",
+		MsgDebugCurrentForm:  "Current Form:",
+		MsgDebugID:           "ID",
+		MsgDebugName:         "Name",
+		MsgDebugIndex:        "Index",
+		MsgDebugFormValues:   "Current Form Values:",
+		MsgDebugGlobalValues: "Global Values:",
+		MsgDebugNavigation:   "Navigation:",
+		MsgDebugHasPrevious:  "Has Previous",
+		MsgDebugHasNext:      "Has Next",
+		MsgDebugProgress:     "Progress",
+		MsgDebugFormState:    "Form State:",
+		MsgDebugState:        "State",
+		MsgDebugErrors:       "Errors",
+		MsgDebugSkipped:      "Skipped Forms:",
+		MsgDebugSkipNoReads:  "skip condition met (no reads declared)",
+		MsgNoCurrentForm:     "No current form",
+		MsgEmpty:             "(empty)",
+		MsgNil:               "(nil)",
+		MsgStateNormal:       "Normal",
+		MsgStateCompleted:    "Completed",
+		MsgStateAborted:      "Aborted",
+		MsgStateUnknown:      "Unknown(%d)",
+	},
+	"de": {
+		MsgNoForms:           "Keine Formulare verfügbar",
+		MsgActiveTitle:       "%s - %s (%.0f%%)",
+		MsgQuitConfirm:       "Beenden? Ihre Antworten gehen verloren.",
+		MsgHelpQuit:          "beenden",
+		MsgHelpScroll:        "blättern",
+		MsgHelpFinish:        "abschließen",
+		MsgHelpYes:           "ja",
+		MsgHelpNo:            "nein",
+		MsgHelpInspect:       "untersuchen",
+		MsgHelpTab:           "nächster Reiter",
+		MsgHelpEdit:          "bearbeiten",
+		MsgHelpJump:          "springen",
+		MsgHelpRerun:         "Überspringen neu prüfen",
+		MsgHelpSave:          "speichern",
+		MsgHelpCancel:        "abbrechen",
+		MsgHelpClose:         "schließen",
+		MsgInspector:         "🔍 INSPEKTOR",
+		MsgInspectorValues:   "Werte",
+		MsgInspectorForms:    "Formulare",
+		MsgInspectorHistory:  "Verlauf",
+		MsgInspectorEvents:   "Ereignisse",
+		MsgInspectorSkip:     "überspringen",
+		MsgInspectorShow:     "anzeigen",
+		MsgInspectorCurrent:  "aktuell",
+		MsgInspectorOwns:     "Besitzt:",
+		MsgInspectorDerived:  "abgeleitet, schreibgeschützt",
+		MsgTimeRemaining:     "⏱ noch %s",
+		MsgNoFlows:           "Keine Abläufe verfügbar",
+		MsgHelpMove:          "bewegen",
+		MsgHelpStart:         "starten",
+		MsgLauncherCompleted: "✓ %s abgeschlossen",
+		MsgLauncherAborted:   "%s wurde nicht abgeschlossen",
+		MsgDebugEnabled:      "Debug-Modus aktiviert",
+		MsgTooSmall:          "Terminal zu klein\n%dx%d (benötigt %dx%d)",
+		MsgLoadingForm:       "Formular wird geladen...",
+		MsgLoadingNamed:      "%s wird geladen...",
+		MsgCompletedTitle:    "%s - Abgeschlossen",
+		MsgErrorTitle:        "%s - Fehler",
+		MsgErrorsOccurred:    "Folgende Fehler sind aufgetreten:",
+		MsgFormError:         "Formular '%s': %s",
+		MsgSummary:           "Zusammenfassung:",
+		MsgAllValues:         "Alle Werte:",
+		MsgNoValues:          "Keine Werte vorhanden.",
+		MsgErrors:            "Fehler: ",
+		MsgStep:              "Schritt %d von %d: %s",
+		MsgDebugPanel:        "🐛 DEBUG-PANEL",
+		MsgDebugCurrentForm:  "Aktuelles Formular:",
+		MsgDebugID:           "ID",
+		MsgDebugName:         "Name",
+		MsgDebugIndex:        "Index",
+		MsgDebugFormValues:   "Werte des Formulars:",
+		MsgDebugGlobalValues: "Globale Werte:",
+		MsgDebugNavigation:   "Navigation:",
+		MsgDebugHasPrevious:  "Hat Vorheriges",
+		MsgDebugHasNext:      "Hat Nächstes",
+		MsgDebugProgress:     "Fortschritt",
+		MsgDebugFormState:    "Formularstatus:",
+		MsgDebugState:        "Status",
+		MsgDebugErrors:       "Fehler",
+		MsgDebugSkipped:      "Übersprungene Formulare:",
+		MsgDebugSkipNoReads:  "Überspringbedingung erfüllt (keine Lesezugriffe deklariert)",
+		MsgNoCurrentForm:     "Kein aktuelles Formular",
+		MsgEmpty:             "(leer)",
+		MsgNil:               "(nil)",
+		MsgStateNormal:       "Normal",
+		MsgStateCompleted:    "Abgeschlossen",
+		MsgStateAborted:      "Abgebrochen",
+		MsgStateUnknown:      "Unbekannt(%d)",
+	},
+	"ja": {
+		MsgNoForms:           "利用できるフォームがありません",
+		MsgActiveTitle:       "%s - %s (%.0f%%)",
+		MsgQuitConfirm:       "終了しますか？入力内容は失われます。",
+		MsgHelpQuit:          "終了",
+		MsgHelpScroll:        "スクロール",
+		MsgHelpFinish:        "完了",
+		MsgHelpYes:           "はい",
+		MsgHelpNo:            "いいえ",
+		MsgHelpInspect:       "インスペクタ",
+		MsgHelpTab:           "次のタブ",
+		MsgHelpEdit:          "編集",
+		MsgHelpJump:          "移動",
+		MsgHelpRerun:         "スキップ条件を再評価",
+		MsgHelpSave:          "保存",
+		MsgHelpCancel:        "キャンセル",
+		MsgHelpClose:         "閉じる",
+		MsgInspector:         "🔍 インスペクタ",
+		MsgInspectorValues:   "値",
+		MsgInspectorForms:    "フォーム",
+		MsgInspectorHistory:  "履歴",
+		MsgInspectorEvents:   "イベント",
+		MsgInspectorSkip:     "スキップ",
+		MsgInspectorShow:     "表示",
+		MsgInspectorCurrent:  "現在",
+		MsgInspectorOwns:     "所有:",
+		MsgInspectorDerived:  "派生・読み取り専用",
+		MsgTimeRemaining:     "⏱ 残り %s",
+		MsgNoFlows:           "利用できるフローがありません",
+		MsgHelpMove:          "移動",
+		MsgHelpStart:         "開始",
+		MsgLauncherCompleted: "✓ %s 完了",
+		MsgLauncherAborted:   "%s は完了していません",
+		MsgDebugEnabled:      "デバッグモード有効",
+		MsgTooSmall:          "端末が小さすぎます\n%dx%d（必要: %dx%d）",
+		MsgLoadingForm:       "フォームを読み込み中...",
+		MsgLoadingNamed:      "%s を読み込み中...",
+		MsgCompletedTitle:    "%s - 完了",
+		MsgErrorTitle:        "%s - エラー",
+		MsgErrorsOccurred:    "次のエラーが発生しました:",
+		MsgFormError:         "フォーム '%s': %s",
+		MsgSummary:           "概要:",
+		MsgAllValues:         "すべての値:",
+		MsgNoValues:          "表示する値がありません。",
+		MsgErrors:            "エラー: ",
+		MsgStep:              "ステップ %d/%d: %s",
+		MsgDebugPanel:        "🐛 デバッグパネル",
+		MsgDebugCurrentForm:  "現在のフォーム:",
+		MsgDebugID:           "ID",
+		MsgDebugName:         "名前",
+		MsgDebugIndex:        "位置",
+		MsgDebugFormValues:   "フォームの値:",
+		MsgDebugGlobalValues: "グローバル値:",
+		MsgDebugNavigation:   "ナビゲーション:",
+		MsgDebugHasPrevious:  "前あり",
+		MsgDebugHasNext:      "次あり",
+		MsgDebugProgress:     "進捗",
+		MsgDebugFormState:    "フォームの状態:",
+		MsgDebugState:        "状態",
+		MsgDebugErrors:       "エラー",
+		MsgDebugSkipped:      "スキップされたフォーム:",
+		MsgDebugSkipNoReads:  "スキップ条件を満たしました（読み取りキー未宣言）",
+		MsgNoCurrentForm:     "現在のフォームはありません",
+		MsgEmpty:             "（空）",
+		MsgNil:               "（なし）",
+		MsgStateNormal:       "入力中",
+		MsgStateCompleted:    "完了",
+		MsgStateAborted:      "中断",
+		MsgStateUnknown:      "不明(%d)",
+	},
+}
+
+// GetLocale retrieves the message catalog registered for a locale.
+// Returns the catalog and true if found, or nil and false if not found.
+func GetLocale(locale string) (Messages, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	messages, exists := locales[locale]
+	return messages, exists
+}
+
+// GetAvailableLocales returns a list of all registered locale names.
+func GetAvailableLocales() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	names := make([]string, 0, len(locales)+1)
+	names = append(names, AutoLocale)
+	for name := range locales {
+		names = append(names, name)
+	}
+	return names
+}
+
+// RegisterLocale adds or replaces the message catalog for a locale.
+// Missing keys fall back to the English catalog.
+func RegisterLocale(locale string, messages Messages) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[locale] = messages
+}
+
+// resolveMessages builds the catalog for a locale with per-key overrides applied.
+// Keys missing from the locale fall back to English. A regional locale such as
+// "de_AT" falls back to its language when it is not registered itself.
+func resolveMessages(locale string, overrides Messages) Messages {
+	if locale == AutoLocale {
+		locale = environmentLocale()
+	}
+
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	resolved := make(Messages, len(locales[DefaultLocale])+len(overrides))
+	for key, text := range locales[DefaultLocale] {
+		resolved[key] = text
+	}
+
+	catalog, exists := locales[locale]
+	if !exists {
+		catalog = locales[languageOf(locale)]
+	}
+	for key, text := range catalog {
+		resolved[key] = text
+	}
+
+	for key, text := range overrides {
+		resolved[key] = text
+	}
+	return resolved
+}
+
+// environmentLocale returns the locale named by the environment, or DefaultLocale.
+func environmentLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" || value == "C" || value == "POSIX" {
+			continue
+		}
+		if i := strings.IndexAny(value, ".@"); i >= 0 {
+			value = value[:i]
+		}
+		return value
+	}
+	return DefaultLocale
+}
+
+// languageOf returns the language part of a locale such as "de_DE" or "ja-JP".
+func languageOf(locale string) string {
+	if i := strings.IndexAny(locale, "_-"); i >= 0 {
+		return strings.ToLower(locale[:i])
+	}
+	return strings.ToLower(locale)
+}
@@ -0,0 +1,58 @@
+package bobarista
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBuiltInCatalogsCoverEveryKey(t *testing.T) {
+	keys := make(map[MessageKey]bool, len(messageKeys))
+	for _, key := range MessageKeys() {
+		if keys[key] {
+			t.Errorf("MessageKeys lists %q twice", key)
+		}
+		keys[key] = true
+	}
+
+	for locale, catalog := range locales {
+		t.Run(locale, func(t *testing.T) {
+			for key := range keys {
+				if _, ok := catalog[key]; !ok {
+					t.Errorf("%s is missing %q", locale, key)
+				}
+			}
+			for key := range catalog {
+				if !keys[key] {
+					t.Errorf("%s has key %q missing from MessageKeys", locale, key)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterLocaleConcurrently(t *testing.T) {
+	t.Cleanup(func() {
+		localesMu.Lock()
+		defer localesMu.Unlock()
+		delete(locales, "test")
+	})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterLocale("test", Messages{MsgHelpQuit: string(rune('a' + i))})
+		}()
+		go func() {
+			defer wg.Done()
+			resolveMessages("test", nil)
+			GetAvailableLocales()
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := GetLocale("test"); !ok {
+		t.Error("expected the test locale to be registered")
+	}
+}
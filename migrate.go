@@ -0,0 +1,91 @@
+package bobarista
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AnswersVersionKey is the key under which the flow version is stored in answers files.
+// It is removed from the values when the file is loaded.
+const AnswersVersionKey = "__version"
+
+// Migration transforms stored values from one flow version into the shape of the next.
+type Migration func(values *FormValues) error
+
+// Migrate upgrades values stored by the given version of the flow to the current version,
+// running the registered migrations in order. Versions without a migration leave the values unchanged.
+// It fails with ErrUnsupportedVersion if the values come from a newer version.
+func (f *Bobarista) Migrate(values *FormValues, from int) error {
+	if from > f.config.Version {
+		return fmt.Errorf("%w: stored version %d is newer than %d", ErrUnsupportedVersion, from, f.config.Version)
+	}
+
+	for version := from; version < f.config.Version; version++ {
+		migrate, exists := f.config.Migrations[version]
+		if !exists {
+			f.debugLog(fmt.Sprintf("No migration from version %d", version))
+			continue
+		}
+		f.infoLog(fmt.Sprintf("Migrating values from version %d to %d", version, version+1))
+		if err := migrate(values); err != nil {
+			return fmt.Errorf("migration from version %d failed: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// RenameKey returns a migration that moves a value to a new key.
+// An existing value under the new key is kept.
+func RenameKey(from, to string) Migration {
+	return func(values *FormValues) error {
+		value, exists := (*values)[from]
+		if !exists {
+			return nil
+		}
+		if !values.Has(to) {
+			(*values)[to] = value
+		}
+		values.Delete(from)
+		return nil
+	}
+}
+
+// DropKeys returns a migration that removes the given keys, for example those of a removed form.
+func DropKeys(keys ...string) Migration {
+	return func(values *FormValues) error {
+		for _, key := range keys {
+			values.Delete(key)
+		}
+		return nil
+	}
+}
+
+// LoadVersionedAnswers reads a JSON object of answers and the flow version that saved them.
+// Files without a version are reported as version 0.
+func LoadVersionedAnswers(path string) (FormValues, int, error) {
+	values, err := loadAnswersFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	version := 0
+	if stored, exists := values.Get(AnswersVersionKey); exists {
+		version, err = strconv.Atoi(stored)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid answers file %s: version %q is not a number", path, stored)
+		}
+		values.Delete(AnswersVersionKey)
+	}
+	return values, version, nil
+}
+
+// SaveVersionedAnswers writes the values together with the flow version to the given file.
+// A version of 0 is not stored.
+func SaveVersionedAnswers(path string, version int, values FormValues) error {
+	if version == 0 {
+		return SaveAnswers(path, values)
+	}
+	versioned := values.Copy()
+	versioned.Set(AnswersVersionKey, strconv.Itoa(version))
+	return SaveAnswers(path, versioned)
+}
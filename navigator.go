@@ -1,11 +1,14 @@
 package bobarista
 
+import "fmt"
+
 // Navigator manages form navigation and flow control within a Bobarista form flow.
 // It handles moving between forms, tracking history, and determining valid navigation paths.
 type Navigator struct {
 	forms      []Form
 	currentIdx int
 	history    []int
+	skipped    []int
 }
 
 // NewNavigator creates a new Navigator with the provided forms.
@@ -27,14 +30,26 @@ func (n *Navigator) Current() *Form {
 }
 
 // Next determines the index of the next form to navigate to.
-// It considers custom navigation handlers and skip conditions.
+// It considers navigation rules, custom navigation handlers and skip conditions.
 // Returns -1 if no more forms are available, -2 if the flow should complete.
 func (n *Navigator) Next(data FormData) (int, error) {
+	n.skipped = n.skipped[:0]
 	current := n.Current()
 	if current == nil {
 		return n.findNextValidForm(0, data)
 	}
 
+	for _, rule := range current.NavigationRules {
+		if !rule.When.Eval(data.Values) {
+			continue
+		}
+		_, nextIdx, err := n.GetFormByID(rule.Target)
+		if err != nil {
+			return -1, fmt.Errorf("%w: '%s'", ErrUnknownNavigationTarget, rule.Target)
+		}
+		return nextIdx, nil
+	}
+
 	if current.NextForm != nil {
 		nextIdx := current.NextForm(&data)
 		if nextIdx == -1 {
@@ -111,6 +126,13 @@ func (n *Navigator) GetCurrentIndex() int {
 	return n.currentIdx
 }
 
+// GetHistory returns the indices of the forms visited before the current one, oldest first.
+func (n *Navigator) GetHistory() []int {
+	history := make([]int, len(n.history))
+	copy(history, n.history)
+	return history
+}
+
 // GetProgress calculates the current progress as a percentage.
 // Returns 0.0 if no forms exist or no form is active.
 func (n *Navigator) GetProgress() float64 {
@@ -126,7 +148,9 @@ func (n *Navigator) GetProgress() float64 {
 // findNextValidForm searches for the next form that should not be skipped.
 // It starts from startIdx and checks each form's skip condition.
 // Returns -1 if no valid forms are found.
+// The indices of forms skipped along the way are available from LastSkipped.
 func (n *Navigator) findNextValidForm(startIdx int, globalData FormData) (int, error) {
+	n.skipped = n.skipped[:0]
 	for i := startIdx; i < len(n.forms); i++ {
 		form := &n.forms[i]
 
@@ -138,15 +162,25 @@ func (n *Navigator) findNextValidForm(startIdx int, globalData FormData) (int, e
 		if form.ShouldSkip == nil || !form.ShouldSkip(&tempData, &globalData) {
 			return i, nil
 		}
+		n.skipped = append(n.skipped, i)
 	}
 	return -1, nil
 }
 
+// LastSkipped returns the indices of the forms skipped by the most recent search
+// for the next valid form. Custom navigation handlers do not record skipped forms.
+func (n *Navigator) LastSkipped() []int {
+	skipped := make([]int, len(n.skipped))
+	copy(skipped, n.skipped)
+	return skipped
+}
+
 // Reset resets the navigator to its initial state.
 // This clears the current form and navigation history.
 func (n *Navigator) Reset() {
 	n.currentIdx = -1
 	n.history = make([]int, 0)
+	n.skipped = nil
 }
 
 // GetFormByID finds a form by its ID and returns the form, its index, and any error.
@@ -173,7 +207,9 @@ func (n *Navigator) GetFormsByGroup(group string) []Form {
 }
 
 // ValidateNavigation validates the form configuration and returns any errors found.
-// It checks for missing forms, empty IDs, duplicate IDs, and missing generators.
+// It checks for missing forms, empty IDs, duplicate IDs, missing generators,
+// and declared navigation targets that do not exist.
+// A form with a LoadedGenerator satisfies the generator requirement.
 func (n *Navigator) ValidateNavigation() []error {
 	var errors []error
 
@@ -198,9 +234,18 @@ func (n *Navigator) ValidateNavigation() []error {
 	}
 
 	for _, form := range n.forms {
-		if form.Generator == nil {
+		if form.Generator == nil && form.LoadedGenerator == nil {
 			errors = append(errors, NewCupSleeveError(form.ID, ErrNoGenerator))
 		}
+		for _, target := range form.declaredTargets() {
+			if _, exists := idMap[target]; !exists {
+				errors = append(errors, NewCupSleeveError(form.ID,
+					fmt.Errorf("%w: '%s'", ErrUnknownNavigationTarget, target)))
+			}
+		}
+		for _, err := range form.exprErrs {
+			errors = append(errors, NewCupSleeveError(form.ID, err))
+		}
 	}
 
 	return errors
@@ -0,0 +1,228 @@
+package bobarista
+
+import (
+	"fmt"
+	"sort"
+)
+
+// valueClaim records that a form contributed a global value.
+// The value the key held before the claim is kept so it can be restored.
+type valueClaim struct {
+	formID      string
+	previous    *string
+	hasPrevious bool
+}
+
+// valueOwnership tracks which forms set each key in the global data.
+// Each key holds a stack of claims, the current owner last, so a value
+// overridden by a later form comes back when that form leaves the path.
+type valueOwnership struct {
+	claims    map[string][]valueClaim
+	completed map[string]bool
+	seeds     map[string]bool
+}
+
+// newValueOwnership creates an empty ownership tracker.
+func newValueOwnership() *valueOwnership {
+	return &valueOwnership{
+		claims:    make(map[string][]valueClaim),
+		completed: make(map[string]bool),
+		seeds:     make(map[string]bool),
+	}
+}
+
+// seed marks keys whose values were prefilled. A prefilled value only seeds the
+// form that submits it, so it is not restored when that form's claim is retracted.
+func (o *valueOwnership) seed(values FormValues) {
+	for key := range values {
+		o.seeds[key] = true
+	}
+}
+
+// reset drops all claims, keeping the prefilled keys.
+func (o *valueOwnership) reset() {
+	o.claims = make(map[string][]valueClaim)
+	o.completed = make(map[string]bool)
+}
+
+// record claims every global key the form wrote, even if it wrote the value already
+// there. before is a copy of the global values taken before the form completed.
+// A form that claims a key again moves to the top of the key's stack; if it already
+// owned the key, the value from before its first claim is kept.
+func (o *valueOwnership) record(formID string, written []string, before FormValues) {
+	for _, key := range written {
+		prev, existed := before[key]
+		old, held, top := o.remove(key, formID)
+		claim := valueClaim{formID: formID}
+		switch {
+		case held && top:
+			claim.previous = old.previous
+			claim.hasPrevious = old.hasPrevious
+		case existed && !(len(o.claims[key]) == 0 && o.seeds[key]):
+			claim.previous = prev
+			claim.hasPrevious = true
+		}
+		o.claims[key] = append(o.claims[key], claim)
+	}
+	o.completed[formID] = true
+}
+
+// remove drops the form's claim on key without changing the global value.
+// The claim above it inherits its previous value, so the stack stays consistent.
+// It reports whether the form held the claim and whether it was the current owner.
+func (o *valueOwnership) remove(key, formID string) (valueClaim, bool, bool) {
+	stack := o.claims[key]
+	for i, claim := range stack {
+		if claim.formID != formID {
+			continue
+		}
+		top := i == len(stack)-1
+		if !top {
+			stack[i+1].previous = claim.previous
+			stack[i+1].hasPrevious = claim.hasPrevious
+		}
+		stack = append(stack[:i:i], stack[i+1:]...)
+		if len(stack) == 0 {
+			delete(o.claims, key)
+		} else {
+			o.claims[key] = stack
+		}
+		return claim, true, top
+	}
+	return valueClaim{}, false, false
+}
+
+// owner returns the ID of the form that currently owns the given key.
+func (o *valueOwnership) owner(key string) (string, bool) {
+	stack := o.claims[key]
+	if len(stack) == 0 {
+		return "", false
+	}
+	return stack[len(stack)-1].formID, true
+}
+
+// keysOf returns the sorted keys currently owned by the given form.
+func (o *valueOwnership) keysOf(formID string) []string {
+	var keys []string
+	for key := range o.claims {
+		if owner, _ := o.owner(key); owner == formID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// claimedBy returns the sorted keys the given form has a claim on, owned or overridden.
+func (o *valueOwnership) claimedBy(formID string) []string {
+	var keys []string
+	for key, stack := range o.claims {
+		for _, claim := range stack {
+			if claim.formID == formID {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// retract removes the claims of the given form and returns the affected keys.
+// Keys the form currently owns are restored to the value they held before its
+// claim, which is the previous owner's value or the value from before any form.
+func (o *valueOwnership) retract(formID string, global FormValues) []string {
+	keys := o.claimedBy(formID)
+	for _, key := range keys {
+		claim, _, top := o.remove(key, formID)
+		if !top {
+			continue
+		}
+		if claim.hasPrevious {
+			if claim.previous != nil {
+				global.Set(key, *claim.previous)
+			} else {
+				global[key] = nil
+			}
+		} else {
+			global.Delete(key)
+		}
+	}
+	delete(o.completed, formID)
+	return keys
+}
+
+// uncomplete marks the form as no longer completed, keeping its claims.
+// Its values are retracted when the flow completes unless it is completed again.
+func (o *valueOwnership) uncomplete(formID string) {
+	delete(o.completed, formID)
+}
+
+// offPath returns the forms that own values but are no longer completed.
+func (o *valueOwnership) offPath() []string {
+	seen := make(map[string]bool)
+	var formIDs []string
+	for _, stack := range o.claims {
+		for _, claim := range stack {
+			if !o.completed[claim.formID] && !seen[claim.formID] {
+				seen[claim.formID] = true
+				formIDs = append(formIDs, claim.formID)
+			}
+		}
+	}
+	sort.Strings(formIDs)
+	return formIDs
+}
+
+// writtenKeys returns the keys set in after since the shallow copy before was taken.
+// Set and Merge always store a new pointer, so a key written with the value it
+// already held is included.
+func writtenKeys(before, after FormValues) []string {
+	var keys []string
+	for key, value := range after {
+		if prev, existed := before[key]; !existed || prev != value {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// retractValues removes the global values contributed by the given form.
+func (f *Bobarista) retractValues(formID string) {
+	if f.ownership == nil || f.globalData == nil {
+		return
+	}
+	keys := f.ownership.retract(formID, *f.globalData.Values)
+	if len(keys) > 0 {
+		f.infoLog(fmt.Sprintf("Retracted values %v contributed by form '%s'", keys, formID))
+		f.updateDerived()
+	}
+}
+
+// retractOffPath removes the global values contributed by forms that are no longer on the path.
+func (f *Bobarista) retractOffPath() {
+	if f.ownership == nil {
+		return
+	}
+	for _, formID := range f.ownership.offPath() {
+		f.retractValues(formID)
+	}
+}
+
+// GetValueOwner returns the ID of the form that contributed the given global key.
+// Values set outside of form completion, such as in OnInit, have no owner.
+func (f *Bobarista) GetValueOwner(key string) (string, bool) {
+	if f.ownership == nil {
+		return "", false
+	}
+	return f.ownership.owner(key)
+}
+
+// GetOwnedKeys returns the global keys contributed by the given form, sorted by name.
+func (f *Bobarista) GetOwnedKeys(formID string) []string {
+	if f.ownership == nil {
+		return nil
+	}
+	return f.ownership.keysOf(formID)
+}
@@ -0,0 +1,140 @@
+package bobarista
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestValueOwnershipRetract(t *testing.T) {
+	type step struct {
+		formID  string
+		values  map[string]string
+		retract bool
+	}
+
+	tests := []struct {
+		name      string
+		initial   map[string]string
+		seeded    bool
+		steps     []step
+		wantValue string
+		wantSet   bool
+		wantOwner string
+	}{
+		{
+			name: "override then retract restores previous owner",
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "b", values: map[string]string{"plan": "pro"}},
+				{formID: "b", retract: true},
+			},
+			wantValue: "basic",
+			wantSet:   true,
+			wantOwner: "a",
+		},
+		{
+			name: "retract only owner removes value",
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "a", retract: true},
+			},
+		},
+		{
+			name:    "retract only owner restores unowned value",
+			initial: map[string]string{"plan": "trial"},
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "a", retract: true},
+			},
+			wantValue: "trial",
+			wantSet:   true,
+		},
+		{
+			name: "retract after earlier form reclaims key",
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "b", values: map[string]string{"plan": "pro"}},
+				{formID: "a", values: map[string]string{"plan": "free"}},
+				{formID: "a", retract: true},
+			},
+			wantValue: "pro",
+			wantSet:   true,
+			wantOwner: "b",
+		},
+		{
+			name: "unchanged value is claimed",
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "b", values: map[string]string{"plan": "basic"}},
+				{formID: "b", retract: true},
+			},
+			wantValue: "basic",
+			wantSet:   true,
+			wantOwner: "a",
+		},
+		{
+			name:    "reclaim keeps value from before first claim",
+			initial: map[string]string{"plan": "trial"},
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "a", values: map[string]string{"plan": "pro"}},
+				{formID: "a", retract: true},
+			},
+			wantValue: "trial",
+			wantSet:   true,
+		},
+		{
+			name:    "retract confirmed prefilled value removes it",
+			initial: map[string]string{"plan": "trial"},
+			seeded:  true,
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "trial"}},
+				{formID: "a", retract: true},
+			},
+		},
+		{
+			name: "retract overridden owner keeps current value",
+			steps: []step{
+				{formID: "a", values: map[string]string{"plan": "basic"}},
+				{formID: "b", values: map[string]string{"plan": "pro"}},
+				{formID: "a", retract: true},
+				{formID: "b", retract: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownership := newValueOwnership()
+			global := NewFormValues()
+			for key, value := range tt.initial {
+				global.Set(key, value)
+			}
+			if tt.seeded {
+				ownership.seed(*global)
+			}
+
+			for _, s := range tt.steps {
+				if s.retract {
+					ownership.retract(s.formID, *global)
+					continue
+				}
+				before := global.Copy()
+				pointers := maps.Clone(*global)
+				for key, value := range s.values {
+					global.Set(key, value)
+				}
+				ownership.record(s.formID, writtenKeys(pointers, *global), before)
+			}
+
+			value, set := global.Get("plan")
+			if set != tt.wantSet || value != tt.wantValue {
+				t.Errorf("plan = %q (set %t), want %q (set %t)", value, set, tt.wantValue, tt.wantSet)
+			}
+			owner, _ := ownership.owner("plan")
+			if owner != tt.wantOwner {
+				t.Errorf("owner = %q, want %q", owner, tt.wantOwner)
+			}
+		})
+	}
+}
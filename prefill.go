@@ -0,0 +1,130 @@
+package bobarista
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// applyPrefill populates the global data from defaults, previous answers, and the environment.
+// Later sources take precedence: defaults, then previous answers, then environment variables.
+func (f *Bobarista) applyPrefill() error {
+	if f.prefilled {
+		return nil
+	}
+	f.prefilled = true
+
+	for key, value := range f.config.Defaults {
+		f.globalData.Values.Set(key, value)
+	}
+
+	if f.config.PreviousAnswersFile != "" {
+		answers, version, err := LoadVersionedAnswers(f.config.PreviousAnswersFile)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to load previous answers: %w", err)
+		}
+		f.debugLog(fmt.Sprintf("Loaded %d previous answers from %s", len(answers), f.config.PreviousAnswersFile))
+		if err == nil {
+			if err := f.Migrate(&answers, version); err != nil {
+				return fmt.Errorf("failed to migrate previous answers: %w", err)
+			}
+		}
+		f.globalData.Values.Merge(&answers)
+	}
+
+	if f.config.EnvPrefix != "" {
+		for _, entry := range os.Environ() {
+			name, value, found := strings.Cut(entry, "=")
+			if !found || !strings.HasPrefix(name, f.config.EnvPrefix) {
+				continue
+			}
+			key := strings.ToLower(strings.TrimPrefix(name, f.config.EnvPrefix))
+			if key == "" {
+				continue
+			}
+			f.debugLog(fmt.Sprintf("Prefilling '%s' from environment variable %s", key, name))
+			f.globalData.Values.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+// LoadAnswers reads a JSON object of answers from the given file, without its version.
+// The returned error wraps os.ErrNotExist if the file does not exist.
+func LoadAnswers(path string) (FormValues, error) {
+	values, _, err := LoadVersionedAnswers(path)
+	return values, err
+}
+
+// loadAnswersFile reads a JSON object of answers, including the version key if present.
+func loadAnswersFile(path string) (FormValues, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var answers map[string]string
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("invalid answers file %s: %w", path, err)
+	}
+
+	values := make(FormValues, len(answers))
+	for key, value := range answers {
+		values.Set(key, value)
+	}
+	return values, nil
+}
+
+// SaveAnswers writes the non-nil values as a JSON object to the given file,
+// readable only by its owner. Parent directories are created as needed.
+func SaveAnswers(path string, values FormValues) error {
+	answers := make(map[string]string, len(values))
+	for key, valuePtr := range values {
+		if valuePtr != nil {
+			answers[key] = *valuePtr
+		}
+	}
+
+	data, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create answers directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0600)
+}
+
+// savedAnswers returns the global values to save as previous answers.
+// Keys claimed by sensitive forms are left out.
+func (f *Bobarista) savedAnswers() FormValues {
+	values := f.globalData.Values.Copy()
+	for _, form := range f.forms {
+		if !form.Sensitive {
+			continue
+		}
+		for _, key := range f.ownership.claimedBy(form.ID) {
+			values.Delete(key)
+		}
+	}
+	return values
+}
+
+// Prefill initializes target from the value stored under key, if any, and returns target.
+// It is intended for binding huh fields to prefilled values:
+//
+//	huh.NewInput().Title("Name").Value(global.Prefill("name", &name))
+func (fv FormValues) Prefill(key string, target *string) *string {
+	if value, exists := fv.Get(key); exists {
+		*target = value
+	}
+	return target
+}
@@ -0,0 +1,284 @@
+package bobarista
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RecordKind identifies the kind of entry in a session recording.
+type RecordKind string
+
+const (
+	// RecordKey is a key press delivered to the flow.
+	RecordKey RecordKind = "key"
+	// RecordResize is a terminal resize delivered to the flow.
+	RecordResize RecordKind = "resize"
+	// RecordNavigation is a navigation decision made by the flow.
+	RecordNavigation RecordKind = "navigation"
+)
+
+// RecordedKey is the serialized form of a tea.KeyMsg.
+type RecordedKey struct {
+	Type  tea.KeyType `json:"type"`
+	Runes string      `json:"runes,omitempty"`
+	Alt   bool        `json:"alt,omitempty"`
+	Paste bool        `json:"paste,omitempty"`
+}
+
+// RecordEntry is a single line of a session recording.
+type RecordEntry struct {
+	// Time is when the entry was recorded.
+	Time time.Time `json:"time"`
+
+	// Kind identifies the kind of entry.
+	Kind RecordKind `json:"kind"`
+
+	// Name is the human-readable key name for key entries.
+	Name string `json:"name,omitempty"`
+
+	// Key is the recorded key for key entries.
+	Key *RecordedKey `json:"key,omitempty"`
+
+	// Width and Height are the terminal size for resize entries.
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+
+	// FormID and NextFormID describe navigation entries.
+	FormID     string `json:"form_id,omitempty"`
+	NextFormID string `json:"next_form_id,omitempty"`
+}
+
+// Msg returns the tea.Msg to replay for the entry.
+// Navigation entries have no message and return nil.
+func (e RecordEntry) Msg() tea.Msg {
+	switch e.Kind {
+	case RecordKey:
+		if e.Key == nil {
+			return nil
+		}
+		return tea.KeyMsg{
+			Type:  e.Key.Type,
+			Runes: []rune(e.Key.Runes),
+			Alt:   e.Key.Alt,
+			Paste: e.Key.Paste,
+		}
+	case RecordResize:
+		return tea.WindowSizeMsg{Width: e.Width, Height: e.Height}
+	default:
+		return nil
+	}
+}
+
+// Recording is a session loaded from a recording file.
+type Recording struct {
+	Entries []RecordEntry
+}
+
+// LoadRecording reads a session recording written by WithRecorder.
+func LoadRecording(path string) (Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Recording{}, err
+	}
+	defer file.Close()
+
+	var recording Recording
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry RecordEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return Recording{}, fmt.Errorf("invalid recording %s line %d: %w", path, line, err)
+		}
+		recording.Entries = append(recording.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return Recording{}, err
+	}
+	return recording, nil
+}
+
+// Messages returns the messages to replay, in recorded order.
+func (r Recording) Messages() []tea.Msg {
+	var msgs []tea.Msg
+	for _, entry := range r.Entries {
+		if msg := entry.Msg(); msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// Navigation returns the recorded navigation decisions, in order.
+func (r Recording) Navigation() []RecordEntry {
+	var entries []RecordEntry
+	for _, entry := range r.Entries {
+		if entry.Kind == RecordNavigation {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// startRecording truncates the recording file and subscribes to navigation events.
+// The file is readable only by its owner and stays open until the program exits.
+func (f *Bobarista) startRecording() {
+	if f.config.RecordFile == "" || f.recording {
+		return
+	}
+
+	file, err := os.OpenFile(f.config.RecordFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err == nil {
+		err = file.Chmod(0600)
+	}
+	if err != nil {
+		if file != nil {
+			file.Close()
+		}
+		f.errorLog(fmt.Errorf("failed to start recording: %w", err))
+		return
+	}
+	f.recordFile = file
+	f.recording = true
+	f.infoLog(fmt.Sprintf("Recording session to %s", f.config.RecordFile))
+
+	f.Subscribe(func(e Event) {
+		if e.Type != EventNavigationDecided {
+			return
+		}
+		f.record(RecordEntry{
+			Time:       e.Time,
+			Kind:       RecordNavigation,
+			FormID:     e.FormID,
+			NextFormID: e.NextFormID,
+		})
+	})
+}
+
+// stopRecording closes the recording file. Entries after it are dropped.
+func (f *Bobarista) stopRecording() {
+	if f.recordFile == nil {
+		return
+	}
+	if err := f.recordFile.Close(); err != nil {
+		f.errorLog(fmt.Errorf("failed to close recording file: %w", err))
+	}
+	f.recordFile = nil
+}
+
+// recordMsg records key presses and resizes delivered to the flow.
+// Printable keys typed into a sensitive form are recorded as '*'.
+func (f *Bobarista) recordMsg(msg tea.Msg) {
+	if !f.recording {
+		return
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if current := f.navigator.Current(); current != nil && current.Sensitive && len(msg.Runes) > 0 {
+			msg.Runes = []rune(strings.Repeat("*", len(msg.Runes)))
+		}
+		f.record(RecordEntry{
+			Kind: RecordKey,
+			Name: msg.String(),
+			Key: &RecordedKey{
+				Type:  msg.Type,
+				Runes: string(msg.Runes),
+				Alt:   msg.Alt,
+				Paste: msg.Paste,
+			},
+		})
+	case tea.WindowSizeMsg:
+		f.record(RecordEntry{
+			Kind:   RecordResize,
+			Width:  msg.Width,
+			Height: msg.Height,
+		})
+	}
+}
+
+// record appends an entry to the recording file.
+// Failures are logged and do not interrupt the flow.
+func (f *Bobarista) record(entry RecordEntry) {
+	if f.recordFile == nil {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		f.errorLog(fmt.Errorf("failed to encode recording entry: %w", err))
+		return
+	}
+
+	if _, err := f.recordFile.Write(append(data, '\n')); err != nil {
+		f.errorLog(fmt.Errorf("failed to write recording entry: %w", err))
+	}
+}
+
+// ValidateReplay checks that the recording at path can be replayed into the flow.
+// It fails with ErrReplayingRecordFile if the flow records to that same file.
+func (f *Bobarista) ValidateReplay(path string) error {
+	if f.config.RecordFile != "" && samePath(f.config.RecordFile, path) {
+		return ErrReplayingRecordFile
+	}
+	return nil
+}
+
+// samePath reports whether two paths refer to the same file.
+// Paths of files that do not exist are compared as absolute paths.
+func samePath(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	if errA == nil && errB == nil {
+		return os.SameFile(infoA, infoB)
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// Replay runs the form flow and feeds it the messages from a recording.
+// Messages are delivered with their original timing, scaled by speed (2 replays twice as fast;
+// zero or less replays without delays). Once the recording is exhausted the flow continues
+// interactively, showing the reproduced final screen.
+// It fails with ErrReplayingRecordFile if the flow records to the replayed file.
+func (f *Bobarista) Replay(path string, speed float64) error {
+	if err := f.ValidateReplay(path); err != nil {
+		return err
+	}
+
+	recording, err := LoadRecording(path)
+	if err != nil {
+		return err
+	}
+
+	f.infoLog(fmt.Sprintf("Replaying %d entries from %s", len(recording.Entries), path))
+	return f.runProgram(func(program *tea.Program) {
+		var last time.Time
+		for _, entry := range recording.Entries {
+			msg := entry.Msg()
+			if msg == nil {
+				continue
+			}
+			if speed > 0 && !last.IsZero() {
+				time.Sleep(time.Duration(float64(entry.Time.Sub(last)) / speed))
+			}
+			last = entry.Time
+			program.Send(msg)
+		}
+	})
+}
@@ -2,55 +2,159 @@ package bobarista
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
 	"strings"
+	"unicode"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/choice404/bobarista/internal"
+	"github.com/muesli/termenv"
 )
 
 // Renderer handles the visual presentation of the Bobarista form flow.
 // It manages layout, styling, and different display states (active, completed, error).
 type Renderer struct {
-	config   Recipe
-	viewport *internal.Viewport
-	styles   *Styles
-	width    int
-	height   int
+	config      Recipe
+	viewport    *internal.Viewport
+	styles      *Styles
+	formTheme   *huh.Theme
+	breakpoints Breakpoints
+	width       int
+	height      int
+	termWidth   int
+	termHeight  int
+	messages    Messages
+	help        help.Model
+	lg          *lipgloss.Renderer
+
+	completionSource string
+	completionWidth  int
 }
 
 // NewRenderer creates a new Renderer with the specified configuration.
 // It initializes the viewport, applies the color scheme, and sets default dimensions.
+// Styles from the configuration replace the themed styles, and style overrides are applied last.
+// With the "auto" scheme and NO_COLOR set, color output is disabled for this renderer only.
 func NewRenderer(config Recipe) *Renderer {
+	lg := lipgloss.DefaultRenderer()
+	if config.ColorScheme == AutoColorScheme && noColor() {
+		var output io.Writer = os.Stdout
+		if config.Output != nil {
+			output = config.Output
+		}
+		lg = lipgloss.NewRenderer(output)
+		lg.SetColorProfile(termenv.Ascii)
+	}
+
 	colorScheme, exists := GetColorScheme(config.ColorScheme)
 	if !exists {
 		colorScheme, _ = GetColorScheme("default")
 	}
 
+	styles := NewStyles(colorScheme)
+	if config.Styles != nil {
+		styles = config.Styles.Copy()
+	}
+	for _, override := range config.StyleOverrides {
+		override(styles)
+	}
+	formTheme := colorScheme.HuhTheme()
+	useRenderer(styles, lg)
+	useRenderer(formTheme, lg)
+
 	return &Renderer{
-		config:   config,
-		viewport: internal.NewViewport(),
-		styles:   NewStyles(colorScheme),
-		width:    config.MaxWidth,
-		height:   24,
+		config:      config,
+		viewport:    internal.NewViewport(),
+		styles:      styles,
+		formTheme:   formTheme,
+		breakpoints: config.Breakpoints.withDefaults(),
+		width:       config.MaxWidth,
+		height:      24,
+		messages:    resolveMessages(config.Locale, config.Messages),
+		help:        newHelp(lg),
+		lg:          lg,
+	}
+}
+
+// useRenderer binds every lipgloss.Style in the struct that target points to,
+// including nested structs, to the given lipgloss renderer.
+func useRenderer(target any, lg *lipgloss.Renderer) {
+	if lg == lipgloss.DefaultRenderer() {
+		return
+	}
+	bindStyles(reflect.ValueOf(target).Elem(), lg)
+}
+
+// bindStyles sets the renderer of the settable styles within value.
+func bindStyles(value reflect.Value, lg *lipgloss.Renderer) {
+	if style, ok := value.Interface().(lipgloss.Style); ok {
+		value.Set(reflect.ValueOf(style.Renderer(lg)))
+		return
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Field(i); field.CanSet() {
+			bindStyles(field, lg)
+		}
 	}
 }
 
 // UpdateSize updates the renderer's dimensions based on terminal size.
 // It respects the maximum width configuration and updates the viewport accordingly.
+// The completion viewport is sized to the space left by the header, footer, and status box.
 func (r *Renderer) UpdateSize(width, height int) {
+	r.termWidth = width
+	r.termHeight = height
 	if width < r.config.MaxWidth {
 		r.width = width - r.styles.Base.GetHorizontalFrameSize()
 	} else {
 		r.width = r.config.MaxWidth - r.styles.Base.GetHorizontalFrameSize()
 	}
 	r.height = height - 4
-	r.viewport.SetSize(r.width, r.height)
+
+	chrome := 2
+	if r.isCompact() {
+		chrome = 1
+	}
+	viewportHeight := max(1, height-chrome-r.styles.Status.GetVerticalFrameSize())
+	r.viewport.SetSize(r.width, viewportHeight)
+}
+
+// isTooSmall reports whether the terminal is below the minimum usable size.
+// Before the first resize the terminal size is unknown and assumed to be large enough.
+func (r *Renderer) isTooSmall() bool {
+	if r.termWidth == 0 && r.termHeight == 0 {
+		return false
+	}
+	return r.termWidth < r.breakpoints.MinWidth || r.termHeight < r.breakpoints.MinHeight
+}
+
+// isNarrow reports whether the debug panel should be stacked below the form.
+func (r *Renderer) isNarrow() bool {
+	return r.termWidth > 0 && r.termWidth < r.breakpoints.NarrowWidth
+}
+
+// isCompact reports whether the header and footer should be collapsed.
+func (r *Renderer) isCompact() bool {
+	return r.termHeight > 0 && r.termHeight < r.breakpoints.CompactHeight
 }
 
 // Render renders the Bobarista form flow based on its current state.
 // It delegates to specific render methods based on the application state.
 func (r *Renderer) Render(cupSleeve *Bobarista) string {
+	if r.isTooSmall() {
+		return r.renderTooSmall()
+	}
+
 	switch cupSleeve.state {
 	case StateError:
 		return r.renderError(cupSleeve)
@@ -66,60 +170,100 @@ func (r *Renderer) Render(cupSleeve *Bobarista) string {
 func (r *Renderer) renderActive(cupSleeve *Bobarista) string {
 	current := cupSleeve.navigator.Current()
 	if current == nil {
-		return r.styles.Base.Render("No forms available")
+		return r.styles.Base.Render(r.text(MsgNoForms))
 	}
 
 	progress := cupSleeve.navigator.GetProgress()
-	title := fmt.Sprintf("%s - %s (%.0f%%)", cupSleeve.config.Title, current.Name, progress)
+	title := r.text(MsgActiveTitle, cupSleeve.config.Title, current.Name, progress)
 	header := r.renderHeader(title)
 
+	var footerText, status string
+	switch {
+	case cupSleeve.confirmingQuit:
+		status = r.text(MsgQuitConfirm)
+		footerText = status + " " + r.renderHelp(cupSleeve)
+	case cupSleeve.currentForm != nil && len(cupSleeve.currentForm.Errors()) > 0:
+		status = r.renderErrors(cupSleeve.currentForm.Errors())
+		footerText = status
+	case cupSleeve.config.Debug:
+		footerText = r.renderHelp(cupSleeve) + " • " + r.text(MsgDebugEnabled)
+	default:
+		footerText = r.renderHelp(cupSleeve)
+	}
+	if countdown := r.renderCountdown(cupSleeve); countdown != "" {
+		footerText += " • " + countdown
+		if status != "" {
+			status += " • "
+		}
+		status += countdown
+	}
+	footer := r.renderFooter(footerText, status)
+
 	var mainContent string
 	if cupSleeve.config.Debug {
-		mainContent = r.renderWithDebugPanel(cupSleeve)
+		mainContent = r.renderWithDebugPanel(cupSleeve, r.bodyHeight(header, footer))
 	} else {
 		mainContent = r.renderFormOnly(cupSleeve)
 	}
 
-	var footerText string
-	if cupSleeve.currentForm != nil && len(cupSleeve.currentForm.Errors()) > 0 {
-		footerText = r.renderErrors(cupSleeve.currentForm.Errors())
-	} else {
-		if cupSleeve.config.Debug {
-			footerText = "Press Ctrl+C to quit • Debug mode enabled"
-		} else {
-			footerText = "Press Ctrl+C to quit"
-		}
-	}
-	footer := r.renderFooter(footerText)
+	return r.joinScreen(header, mainContent, footer)
+}
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, mainContent, footer)
+// renderTooSmall renders the screen shown when the terminal is below the minimum size.
+func (r *Renderer) renderTooSmall() string {
+	message := r.text(MsgTooSmall,
+		r.termWidth, r.termHeight, r.breakpoints.MinWidth, r.breakpoints.MinHeight)
+	return r.lg.Place(
+		r.termWidth,
+		r.termHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		r.styles.Warning.Render(message),
+	)
 }
 
 // renderWithDebugPanel renders the form with a debug panel showing internal state.
-// The layout splits the available width between the form and debug information.
-func (r *Renderer) renderWithDebugPanel(cupSleeve *Bobarista) string {
+// The layout splits the available width between the form and debug information,
+// or stacks the panel below the form on narrow terminals.
+func (r *Renderer) renderWithDebugPanel(cupSleeve *Bobarista, height int) string {
+	if r.isNarrow() {
+		form := r.renderFormOnly(cupSleeve)
+		panelHeight := 0
+		if height > 0 {
+			panelHeight = height - lipgloss.Height(form)
+			if panelHeight < 1 {
+				return form
+			}
+		}
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			form,
+			r.renderDebugPanel(cupSleeve, r.width, panelHeight),
+		)
+	}
+
 	// Split width: 60% for form, 40% for debug panel
 	formWidth := int(float64(r.width) * 0.6)
-	debugWidth := r.width - formWidth - 2
+	debugWidth := r.width - formWidth - r.styles.DebugPanelGap
 
 	var formContent string
 	if cupSleeve.currentForm != nil {
 		formView := cupSleeve.currentForm.View()
-		formContent = lipgloss.NewStyle().
+		formContent = r.lg.NewStyle().
 			Width(formWidth).
 			Render(formView)
 	} else {
-		formContent = lipgloss.NewStyle().
+		formContent = r.lg.NewStyle().
 			Width(formWidth).
-			Render("Loading form...")
+			Render(r.loadingText(cupSleeve))
 	}
 
-	debugContent := r.renderDebugPanel(cupSleeve, debugWidth)
+	debugContent := r.renderDebugPanel(cupSleeve, debugWidth, height)
 
 	return lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		formContent,
-		lipgloss.NewStyle().Width(2).Render(""),
+		r.lg.NewStyle().Width(r.styles.DebugPanelGap).Render(""),
 		debugContent,
 	)
 }
@@ -130,42 +274,61 @@ func (r *Renderer) renderFormOnly(cupSleeve *Bobarista) string {
 		formView := cupSleeve.currentForm.View()
 		return r.styles.Base.Render(formView)
 	}
-	return r.styles.Base.Render("Loading form...")
+	return r.styles.Base.Render(r.loadingText(cupSleeve))
+}
+
+// loadingText returns the placeholder shown while no form is ready.
+// It names the form whose loader is running, if any.
+func (r *Renderer) loadingText(cupSleeve *Bobarista) string {
+	current := cupSleeve.navigator.Current()
+	if cupSleeve.loading && current != nil {
+		return r.styles.Info.Render(r.text(MsgLoadingNamed, current.Name))
+	}
+	return r.text(MsgLoadingForm)
 }
 
 // renderDebugPanel creates a debug panel showing form state, values, and navigation info.
 // This is displayed when debug mode is enabled.
-func (r *Renderer) renderDebugPanel(cupSleeve *Bobarista, width int) string {
+// The interactive inspector replaces it while open.
+func (r *Renderer) renderDebugPanel(cupSleeve *Bobarista, width, height int) string {
+	if cupSleeve.IsInspecting() {
+		return r.renderInspector(cupSleeve, width, height)
+	}
+
 	current := cupSleeve.navigator.Current()
 	if current == nil {
-		return r.styles.Base.Width(width).Render("No current form")
+		return r.styles.Base.Width(width).Render(r.text(MsgNoCurrentForm))
 	}
 
 	var content strings.Builder
 
-	content.WriteString(r.styles.Highlight.Render("🐛 DEBUG PANEL"))
+	content.WriteString(r.styles.Highlight.Render(r.text(MsgDebugPanel)))
 	content.WriteString("\n\n")
 
-	content.WriteString(r.styles.KeyText.Render("Current Form:"))
+	content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugCurrentForm)))
 	content.WriteString("\n")
-	content.WriteString(fmt.Sprintf("  ID: %s\n", r.styles.ValueText.Render(current.ID)))
-	content.WriteString(fmt.Sprintf("  Name: %s\n", r.styles.ValueText.Render(current.Name)))
-	content.WriteString(fmt.Sprintf("  Index: %s\n", r.styles.ValueText.Render(fmt.Sprintf("%d/%d",
-		cupSleeve.navigator.GetCurrentIndex()+1, cupSleeve.navigator.GetFormCount()))))
+	content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugID),
+		r.styles.ValueText.Render(current.ID)))
+	content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugName),
+		r.styles.ValueText.Render(current.Name)))
+	content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugIndex),
+		r.styles.ValueText.Render(fmt.Sprintf("%d/%d",
+			cupSleeve.navigator.GetCurrentIndex()+1, cupSleeve.navigator.GetFormCount()))))
 	content.WriteString("\n")
 
-	content.WriteString(r.styles.KeyText.Render("Current Form Values:"))
+	content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugFormValues)))
 	content.WriteString("\n")
 	currentData := cupSleeve.GetCurrentFormData()
 	if len(*currentData.Values) == 0 {
-		content.WriteString("  " + r.styles.Help.Render("(empty)") + "\n")
+		content.WriteString("  " + r.styles.Help.Render(r.text(MsgEmpty)) + "\n")
 	} else {
-		for key, valuePtr := range *currentData.Values {
-			value := "(nil)"
+		for _, key := range sortedKeys(*currentData.Values) {
+			valuePtr := (*currentData.Values)[key]
+			value := r.text(MsgNil)
 			if valuePtr != nil {
 				value = *valuePtr
 				if value == "" {
-					value = "(empty)"
+					value = r.text(MsgEmpty)
 				}
 			}
 			content.WriteString(fmt.Sprintf("  %s: %s\n",
@@ -175,18 +338,19 @@ func (r *Renderer) renderDebugPanel(cupSleeve *Bobarista, width int) string {
 	}
 	content.WriteString("\n")
 
-	content.WriteString(r.styles.KeyText.Render("Global Values:"))
+	content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugGlobalValues)))
 	content.WriteString("\n")
 	globalData := cupSleeve.GetGlobalData()
 	if len(*globalData.Values) == 0 {
-		content.WriteString("  " + r.styles.Help.Render("(empty)") + "\n")
+		content.WriteString("  " + r.styles.Help.Render(r.text(MsgEmpty)) + "\n")
 	} else {
-		for key, valuePtr := range *globalData.Values {
-			value := "(nil)"
+		for _, key := range sortedKeys(*globalData.Values) {
+			valuePtr := (*globalData.Values)[key]
+			value := r.text(MsgNil)
 			if valuePtr != nil {
 				value = *valuePtr
 				if value == "" {
-					value = "(empty)"
+					value = r.text(MsgEmpty)
 				}
 			}
 			content.WriteString(fmt.Sprintf("  %s: %s\n",
@@ -196,56 +360,106 @@ func (r *Renderer) renderDebugPanel(cupSleeve *Bobarista, width int) string {
 	}
 	content.WriteString("\n")
 
-	content.WriteString(r.styles.KeyText.Render("Navigation:"))
+	content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugNavigation)))
 	content.WriteString("\n")
-	content.WriteString(fmt.Sprintf("  Has Previous: %s\n",
+	content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugHasPrevious),
 		r.styles.ValueText.Render(fmt.Sprintf("%t", cupSleeve.navigator.HasPrevious()))))
-	content.WriteString(fmt.Sprintf("  Has Next: %s\n",
+	content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugHasNext),
 		r.styles.ValueText.Render(fmt.Sprintf("%t", cupSleeve.navigator.HasNext()))))
-	content.WriteString(fmt.Sprintf("  Progress: %s\n",
+	content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugProgress),
 		r.styles.ValueText.Render(fmt.Sprintf("%.1f%%", cupSleeve.navigator.GetProgress()))))
 	content.WriteString("\n")
 
-	content.WriteString(r.styles.KeyText.Render("Form State:"))
+	if skipped := r.debugSkipReasons(cupSleeve); skipped != "" {
+		content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugSkipped)))
+		content.WriteString("\n")
+		content.WriteString(skipped)
+		content.WriteString("\n")
+	}
+
+	content.WriteString(r.styles.KeyText.Render(r.text(MsgDebugFormState)))
 	content.WriteString("\n")
 	if cupSleeve.currentForm != nil {
-		content.WriteString(fmt.Sprintf("  State: %s\n",
+		content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugState),
 			r.styles.ValueText.Render(r.formStateToString(cupSleeve.currentForm.State))))
-		content.WriteString(fmt.Sprintf("  Errors: %s\n",
+		content.WriteString(fmt.Sprintf("  %s: %s\n", r.text(MsgDebugErrors),
 			r.styles.ValueText.Render(fmt.Sprintf("%d", len(cupSleeve.currentForm.Errors())))))
 	} else {
-		content.WriteString("  " + r.styles.Help.Render("No current form") + "\n")
+		content.WriteString("  " + r.styles.Help.Render(r.text(MsgNoCurrentForm)) + "\n")
+	}
+
+	return r.renderDebugBox(content.String(), width, height)
+}
+
+// debugSkipReasons lists the skipped forms in flow order with the values of the keys they read.
+func (r *Renderer) debugSkipReasons(cupSleeve *Bobarista) string {
+	var content strings.Builder
+	for _, form := range cupSleeve.forms {
+		reason, skipped := cupSleeve.GetSkipReason(form.ID)
+		if !skipped {
+			continue
+		}
+		if reason == "" {
+			reason = r.text(MsgDebugSkipNoReads)
+		}
+		content.WriteString(fmt.Sprintf("  %s: %s\n",
+			r.styles.KeyText.Render(form.ID),
+			r.styles.ValueText.Render(reason)))
+	}
+	return content.String()
+}
+
+// bodyHeight returns the rows left for the body below the header and above the footer,
+// or 0 while the terminal size is unknown.
+func (r *Renderer) bodyHeight(header, footer string) int {
+	if r.termHeight == 0 {
+		return 0
+	}
+	height := r.termHeight - lipgloss.Height(header)
+	if footer != "" {
+		height -= lipgloss.Height(footer)
 	}
+	return height
+}
 
-	debugPanel := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(r.styles.KeyText.GetForeground()).
-		Padding(1).
-		Width(width).
-		Height(r.height - 4).
-		Render(content.String())
+// renderDebugBox frames debug panel content at the given width and outer height.
+// Content lines that do not fit are dropped; without room for the frame the panel
+// collapses to its title line. A height of 0 lets the panel fit its content.
+func (r *Renderer) renderDebugBox(content string, width, height int) string {
+	style := r.styles.DebugPanel.Width(width)
+	if height <= 0 {
+		return style.Render(content)
+	}
 
-	return debugPanel
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	inner := height - style.GetVerticalFrameSize()
+	if inner < 1 {
+		return ansi.Truncate(lines[0], width, "…")
+	}
+	if len(lines) > inner {
+		lines = lines[:inner]
+	}
+	return style.Height(inner).Render(strings.Join(lines, "\n"))
 }
 
 // formStateToString converts a huh.FormState to a human-readable string.
 func (r *Renderer) formStateToString(state huh.FormState) string {
 	switch state {
 	case huh.StateNormal:
-		return "Normal"
+		return r.text(MsgStateNormal)
 	case huh.StateCompleted:
-		return "Completed"
+		return r.text(MsgStateCompleted)
 	case huh.StateAborted:
-		return "Aborted"
+		return r.text(MsgStateAborted)
 	default:
-		return fmt.Sprintf("Unknown(%d)", int(state))
+		return r.text(MsgStateUnknown, int(state))
 	}
 }
 
 // renderCompleted renders the completion screen with collected data.
 // It supports scrolling for long content and custom display callbacks.
 func (r *Renderer) renderCompleted(cupSleeve *Bobarista) string {
-	header := r.renderHeader(cupSleeve.config.Title + " - Completed")
+	header := r.renderHeader(r.text(MsgCompletedTitle, cupSleeve.config.Title))
 
 	var content string
 	if cupSleeve.config.DisplayCallback != nil {
@@ -254,29 +468,52 @@ func (r *Renderer) renderCompleted(cupSleeve *Bobarista) string {
 		content = r.renderDefaultCompletion(cupSleeve)
 	}
 
-	lines := strings.Split(content, "\n")
-	r.viewport.SetContent(lines)
+	r.setCompletionContent(content)
 
 	visibleLines := r.viewport.VisibleContent()
 	body := r.styles.Status.Render(strings.Join(visibleLines, "\n"))
 
-	var footerText string
-	if r.viewport.CanScrollUp() || r.viewport.CanScrollDown() {
-		footerText = "↑/↓ to scroll, Enter to finish, Q to quit"
-	} else {
-		footerText = "Press Enter to finish, Q to quit"
-	}
-	footer := r.renderFooter(footerText)
+	footer := r.renderFooter(r.renderHelp(cupSleeve), "")
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+	return r.joinScreen(header, body, footer)
+}
+
+// setCompletionContent wraps the completion content to the available width and loads it
+// into the viewport. The content is only reloaded when it or the width changes, so the
+// scroll position is kept between frames.
+func (r *Renderer) setCompletionContent(content string) {
+	width := r.width - r.styles.Status.GetHorizontalFrameSize()
+	if content == r.completionSource && width == r.completionWidth {
+		return
+	}
+	r.completionSource = content
+	r.completionWidth = width
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if lipgloss.Width(line) <= width {
+			lines = append(lines, line)
+			continue
+		}
+		lines = append(lines, internal.WrapText(line, width)...)
+	}
+	r.viewport.SetContent(lines)
 }
 
 // renderError renders the error screen displaying all accumulated errors.
 func (r *Renderer) renderError(cupSleeve *Bobarista) string {
-	header := r.renderHeader(cupSleeve.config.Title + " - Error")
+	header := r.renderHeader(r.text(MsgErrorTitle, cupSleeve.config.Title))
+
+	body := r.styles.Error.Render(r.errorContent(cupSleeve))
+	footer := r.renderFooter(r.renderHelp(cupSleeve), "")
+
+	return r.joinScreen(header, body, footer)
+}
 
+// errorContent lists the accumulated errors, one per line.
+func (r *Renderer) errorContent(cupSleeve *Bobarista) string {
 	var content strings.Builder
-	content.WriteString("The following errors occurred:\n\n")
+	content.WriteString(r.text(MsgErrorsOccurred) + "\n\n")
 
 	for i, err := range cupSleeve.errors {
 		if i > 0 {
@@ -284,68 +521,174 @@ func (r *Renderer) renderError(cupSleeve *Bobarista) string {
 		}
 
 		if cupSleeveErr, ok := err.(CupSleeveError); ok && cupSleeveErr.FormID != "" {
-			content.WriteString(fmt.Sprintf("Form '%s': %s", cupSleeveErr.FormID, cupSleeveErr.Err.Error()))
+			content.WriteString(r.text(MsgFormError, cupSleeveErr.FormID, cupSleeveErr.Err.Error()))
 		} else {
 			content.WriteString(err.Error())
 		}
 	}
 
-	body := r.styles.Error.Render(content.String())
-	footer := r.renderFooter("Press Q to quit")
-
-	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+	return content.String()
 }
 
 // renderDefaultCompletion creates the default completion display.
 // It shows either specified display keys or all collected values.
 func (r *Renderer) renderDefaultCompletion(cupSleeve *Bobarista) string {
+	return r.summaryContent(cupSleeve, func(value string) string {
+		return r.styles.Highlight.Render(value)
+	})
+}
+
+// summaryContent lists the collected values, passing each value through highlight.
+func (r *Renderer) summaryContent(cupSleeve *Bobarista, highlight func(string) string) string {
 	var content strings.Builder
 
 	globalData := cupSleeve.GetGlobalData()
 
 	if len(r.config.DisplayKeys) > 0 {
 		// Show only specified keys
-		content.WriteString("Summary:\n\n")
+		content.WriteString(r.text(MsgSummary) + "\n\n")
 		for _, key := range r.config.DisplayKeys {
 			if value, exists := globalData.Values.Get(key); exists && value != "" {
 				content.WriteString(fmt.Sprintf("%s: %s\n",
-					r.formatKey(key), r.styles.Highlight.Render(value)))
+					r.formatKey(key), highlight(value)))
 			}
 		}
 	} else {
 		// Show all non-empty values
-		content.WriteString("All Values:\n\n")
+		content.WriteString(r.text(MsgAllValues) + "\n\n")
 		hasValues := false
-		for key, valuePtr := range *globalData.Values {
+		for _, key := range sortedKeys(*globalData.Values) {
+			valuePtr := (*globalData.Values)[key]
 			if valuePtr != nil && *valuePtr != "" {
 				content.WriteString(fmt.Sprintf("%s: %s\n",
-					r.formatKey(key), r.styles.Highlight.Render(*valuePtr)))
+					r.formatKey(key), highlight(*valuePtr)))
 				hasValues = true
 			}
 		}
 
 		if !hasValues {
-			content.WriteString("No values to display.")
+			content.WriteString(r.text(MsgNoValues))
 		}
 	}
 
 	return content.String()
 }
 
+// joinScreen stacks the header, body, and footer, leaving out a hidden footer.
+func (r *Renderer) joinScreen(header, body, footer string) string {
+	if footer == "" {
+		return lipgloss.JoinVertical(lipgloss.Left, header, body)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
 // renderHeader creates a styled header with the specified title.
+// On compact terminals only the title is shown.
 func (r *Renderer) renderHeader(title string) string {
-	return lipgloss.PlaceHorizontal(
+	if r.isCompact() {
+		return r.styles.HeaderText.Render(title)
+	}
+	return r.lg.PlaceHorizontal(
 		r.width,
-		lipgloss.Left,
+		r.styles.HeaderAlign,
 		r.styles.HeaderText.Render(title),
-		lipgloss.WithWhitespaceChars("="),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("12")),
+		lipgloss.WithWhitespaceChars(r.styles.HeaderFiller),
+		lipgloss.WithWhitespaceForeground(r.styles.HeaderFillerColor),
 	)
 }
 
 // renderFooter creates a styled footer with help text.
-func (r *Renderer) renderFooter(text string) string {
-	return r.styles.Help.Render(text)
+// On compact terminals the help is dropped and only the status, such as the quit prompt,
+// form errors or the countdown, is shown, cut to a single line. Without a status the
+// footer is hidden.
+func (r *Renderer) renderFooter(text, status string) string {
+	if r.isCompact() {
+		if status == "" {
+			return ""
+		}
+		line, _, _ := strings.Cut(status, "\n")
+		width := r.width - r.styles.Footer.GetHorizontalFrameSize()
+		return r.styles.Footer.Render(ansi.Truncate(line, width, "…"))
+	}
+	return r.styles.Footer.Render(text)
+}
+
+// renderHelp renders the help line for the key bindings that apply in the current state.
+func (r *Renderer) renderHelp(cupSleeve *Bobarista) string {
+	r.help.Width = r.width
+	return r.help.ShortHelpView(r.helpBindings(cupSleeve))
+}
+
+// helpBindings returns the key bindings shown in the footer for the current state.
+// Empty help descriptions are filled in from the message catalog.
+func (r *Renderer) helpBindings(cupSleeve *Bobarista) []key.Binding {
+	keys := cupSleeve.keys
+	switch {
+	case cupSleeve.IsInspecting():
+		return r.inspectorHelpBindings(cupSleeve)
+	case cupSleeve.confirmingQuit:
+		return []key.Binding{
+			withHelp(keys.ConfirmQuit, r.text(MsgHelpYes)),
+			withHelp(keys.CancelQuit, r.text(MsgHelpNo)),
+		}
+	case cupSleeve.state == StateCompleted:
+		var bindings []key.Binding
+		if r.viewport.CanScrollUp() || r.viewport.CanScrollDown() {
+			bindings = append(bindings, withHelp(keys.scrollBinding(), r.text(MsgHelpScroll)))
+		}
+		return append(bindings,
+			withHelp(keys.Finish, r.text(MsgHelpFinish)),
+			withHelp(keys.Close, r.text(MsgHelpQuit)))
+	case cupSleeve.state == StateError:
+		return []key.Binding{withHelp(keys.Close, r.text(MsgHelpQuit))}
+	case cupSleeve.inspector != nil:
+		return []key.Binding{
+			withHelp(keys.Quit, r.text(MsgHelpQuit)),
+			withHelp(keys.Inspector.Toggle, r.text(MsgHelpInspect)),
+		}
+	default:
+		return []key.Binding{withHelp(keys.Quit, r.text(MsgHelpQuit))}
+	}
+}
+
+// inspectorHelpBindings returns the key bindings shown in the footer while the inspector is open.
+func (r *Renderer) inspectorHelpBindings(cupSleeve *Bobarista) []key.Binding {
+	in := cupSleeve.inspector
+	keys := cupSleeve.keys.Inspector
+	if in.editing {
+		return []key.Binding{
+			withHelp(keys.Select, r.text(MsgHelpSave)),
+			withHelp(keys.Cancel, r.text(MsgHelpCancel)),
+		}
+	}
+
+	bindings := []key.Binding{withHelp(keys.NextTab, r.text(MsgHelpTab))}
+	switch in.tab {
+	case inspectValues:
+		bindings = append(bindings, withHelp(keys.Select, r.text(MsgHelpEdit)))
+	case inspectForms:
+		bindings = append(bindings,
+			withHelp(keys.Select, r.text(MsgHelpJump)),
+			withHelp(keys.Rerun, r.text(MsgHelpRerun)))
+	}
+	return append(bindings, withHelp(keys.Toggle, r.text(MsgHelpClose)))
+}
+
+// newHelp creates the help model used for the footer.
+// Its styles are left plain so the footer style applies to the whole line.
+func newHelp(lg *lipgloss.Renderer) help.Model {
+	model := help.New()
+	plain := lg.NewStyle()
+	model.Styles = help.Styles{
+		Ellipsis:       plain,
+		ShortKey:       plain,
+		ShortDesc:      plain,
+		ShortSeparator: plain,
+		FullKey:        plain,
+		FullDesc:       plain,
+		FullSeparator:  plain,
+	}
+	return model
 }
 
 // renderErrors formats multiple errors for display in the footer.
@@ -355,7 +698,7 @@ func (r *Renderer) renderErrors(errors []error) string {
 	}
 
 	var content strings.Builder
-	content.WriteString("Errors: ")
+	content.WriteString(r.text(MsgErrors))
 
 	for i, err := range errors {
 		if i > 0 {
@@ -368,22 +711,49 @@ func (r *Renderer) renderErrors(errors []error) string {
 }
 
 // formatKey converts underscore-separated keys to human-readable format.
-// For example, "first_name" becomes "First Name".
+// For example, "first_name" becomes "First Name". A label registered in the
+// message catalog under KeyLabel(key) takes precedence, so keys can be translated.
 func (r *Renderer) formatKey(key string) string {
 	if key == "" {
 		return ""
 	}
 
+	if label, exists := r.messages[KeyLabel(key)]; exists {
+		return label
+	}
+
 	parts := strings.Split(key, "_")
 	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		runes := []rune(strings.ToLower(part))
+		if len(runes) > 0 {
+			runes[0] = unicode.ToTitle(runes[0])
+			parts[i] = string(runes)
 		}
 	}
 
 	return strings.Join(parts, " ")
 }
 
+// text returns the catalog string for key, formatted with args when given.
+func (r *Renderer) text(key MessageKey, args ...any) string {
+	text := r.messages[key]
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// sortedKeys returns the keys of the values in alphabetical order.
+// This keeps rendered value lists stable between frames.
+func sortedKeys(values FormValues) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // HandleScroll processes scroll events for the viewport.
 // Positive direction scrolls down, negative scrolls up.
 func (r *Renderer) HandleScroll(direction int) {
@@ -402,8 +772,16 @@ func (r *Renderer) CanScrollDown() bool {
 
 // SetColorScheme updates the renderer's color scheme.
 // If the scheme doesn't exist, the change is ignored.
+// Colors are reapplied on top of any style customizations.
 func (r *Renderer) SetColorScheme(schemeName string) {
 	if colorScheme, exists := GetColorScheme(schemeName); exists {
 		r.styles.ApplyColorScheme(colorScheme)
+		r.formTheme = colorScheme.HuhTheme()
+		useRenderer(r.formTheme, r.lg)
 	}
 }
+
+// FormTheme returns the huh.Theme matching the renderer's color scheme.
+func (r *Renderer) FormTheme() *huh.Theme {
+	return r.formTheme
+}
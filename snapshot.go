@@ -0,0 +1,137 @@
+package bobarista
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Snapshot is a deep copy of the state of a flow, taken after its latest update.
+// Unlike GetGlobalData, snapshots are safe to read from any goroutine.
+type Snapshot struct {
+	// State is the state of the flow.
+	State BobaState
+
+	// FormID is the ID of the current form, or empty if there is none.
+	FormID string
+
+	// Global holds the global values.
+	Global FormValues
+
+	// Forms holds the per-form values, keyed by form ID.
+	Forms map[string]FormValues
+
+	// Errors lists the errors raised so far.
+	Errors []error
+}
+
+// copy returns a deep copy of the snapshot.
+func (s Snapshot) copy() Snapshot {
+	forms := make(map[string]FormValues, len(s.Forms))
+	for id, values := range s.Forms {
+		forms[id] = values.Copy()
+	}
+	s.Global = s.Global.Copy()
+	s.Forms = forms
+	s.Errors = append([]error(nil), s.Errors...)
+	return s
+}
+
+// ValueUpdateMsg sets and deletes global values in a running flow.
+// Send it with Bobarista.Send or SendValue from any goroutine.
+// Updated values are seen by skip conditions, navigation, derived values and the summary;
+// fields of the form already on screen keep their current values.
+type ValueUpdateMsg struct {
+	// Values are set in the global data.
+	Values map[string]string
+
+	// Delete lists keys removed from the global data.
+	Delete []string
+}
+
+// publish stores a snapshot of the current state for concurrent readers.
+// It is called after every Init and Update, on the goroutine running the flow.
+func (f *Bobarista) publish() {
+	snapshot := Snapshot{
+		State:  f.state,
+		Global: *NewFormValues(),
+		Forms:  make(map[string]FormValues, len(f.formValues)),
+		Errors: append([]error(nil), f.errors...),
+	}
+	if current := f.navigator.Current(); current != nil {
+		snapshot.FormID = current.ID
+	}
+	if f.globalData != nil {
+		snapshot.Global = f.globalData.Values.Copy()
+	}
+	for id, values := range f.formValues {
+		snapshot.Forms[id] = values.Copy()
+	}
+
+	f.snapshotMu.Lock()
+	f.snapshot = snapshot
+	f.snapshotMu.Unlock()
+}
+
+// GetSnapshot returns a deep copy of the flow's state as of its latest update.
+// It is safe to call from any goroutine, for example from an autosave or metrics ticker.
+func (f *Bobarista) GetSnapshot() Snapshot {
+	f.snapshotMu.RLock()
+	defer f.snapshotMu.RUnlock()
+	return f.snapshot.copy()
+}
+
+// GetSnapshotValue returns a global value as of the flow's latest update.
+// It is safe to call from any goroutine.
+func (f *Bobarista) GetSnapshotValue(key string) (string, bool) {
+	f.snapshotMu.RLock()
+	defer f.snapshotMu.RUnlock()
+	return f.snapshot.Global.Get(key)
+}
+
+// Send delivers a message to the running flow through its tea.Program.
+// It is safe to call from any goroutine, and fails with ErrNotRunning unless Run is in progress.
+func (f *Bobarista) Send(msg tea.Msg) error {
+	f.snapshotMu.RLock()
+	program := f.program
+	f.snapshotMu.RUnlock()
+
+	if program == nil {
+		return ErrNotRunning
+	}
+	program.Send(msg)
+	return nil
+}
+
+// SendValue sets a global value in the running flow.
+// The value is applied by the flow's own goroutine, after the messages already queued.
+func (f *Bobarista) SendValue(key, value string) error {
+	return f.Send(ValueUpdateMsg{Values: map[string]string{key: value}})
+}
+
+// setProgram records the program running the flow, or clears it when the program exits.
+func (f *Bobarista) setProgram(program *tea.Program) {
+	f.snapshotMu.Lock()
+	f.program = program
+	f.snapshotMu.Unlock()
+}
+
+// setRunning records whether a flow started with Start is running.
+func (f *Bobarista) setRunning(running bool) {
+	f.snapshotMu.Lock()
+	f.running = running
+	f.snapshotMu.Unlock()
+}
+
+// applyValueUpdate applies a ValueUpdateMsg to the global data.
+func (f *Bobarista) applyValueUpdate(msg ValueUpdateMsg) {
+	for key, value := range msg.Values {
+		f.debugLog(fmt.Sprintf("Value update set '%s' to '%s'", key, value))
+		f.globalData.Values.Set(key, value)
+	}
+	for _, key := range msg.Delete {
+		f.debugLog(fmt.Sprintf("Value update deleted '%s'", key))
+		f.globalData.Values.Delete(key)
+	}
+	f.updateDerived()
+}
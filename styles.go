@@ -55,8 +55,29 @@ type Styles struct {
 
 	// Info styles informational messages and neutral feedback.
 	Info lipgloss.Style
+
+	// Footer styles the help line at the bottom of the interface.
+	Footer lipgloss.Style
+
+	// DebugPanel styles the box around the debug panel.
+	DebugPanel lipgloss.Style
+
+	// DebugPanelGap is the number of columns between the form and the debug panel.
+	DebugPanelGap int
+
+	// HeaderFiller is the character used to fill the header line after the title.
+	HeaderFiller string
+
+	// HeaderFillerColor is the color of the header filler characters.
+	HeaderFillerColor lipgloss.TerminalColor
+
+	// HeaderAlign positions the title within the header line.
+	HeaderAlign lipgloss.Position
 }
 
+// StyleOverride customizes a Styles instance after the color scheme has been applied.
+type StyleOverride func(*Styles)
+
 // NewStyles creates a new Styles instance with the specified color scheme.
 // It initializes all style components with appropriate colors and formatting.
 func NewStyles(colorScheme ColorScheme) *Styles {
@@ -130,6 +151,22 @@ func NewStyles(colorScheme ColorScheme) *Styles {
 
 		Info: lipgloss.NewStyle().
 			Foreground(colorScheme.Info),
+
+		Footer: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")),
+
+		DebugPanel: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colorScheme.Tertiary).
+			Padding(1),
+
+		DebugPanelGap: 2,
+
+		HeaderFiller: "=",
+
+		HeaderFillerColor: lipgloss.Color("12"),
+
+		HeaderAlign: lipgloss.Left,
 	}
 }
 
@@ -157,4 +194,18 @@ func (s *Styles) ApplyColorScheme(colorScheme ColorScheme) {
 	s.Success = s.Success.Foreground(colorScheme.Success)
 	s.Warning = s.Warning.Foreground(colorScheme.Warning)
 	s.Info = s.Info.Foreground(colorScheme.Info)
+	s.DebugPanel = s.DebugPanel.BorderForeground(colorScheme.Tertiary)
+}
+
+// Copy returns an independent copy of the styles.
+func (s *Styles) Copy() *Styles {
+	styles := *s
+	return &styles
+}
+
+// SetBorder changes the border of the boxed elements: the status box, the error box, and the debug panel.
+func (s *Styles) SetBorder(border lipgloss.Border) {
+	s.Status = s.Status.BorderStyle(border)
+	s.Error = s.Error.BorderStyle(border)
+	s.DebugPanel = s.DebugPanel.BorderStyle(border)
 }
@@ -0,0 +1,595 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/huh"
+	"github.com/choice404/bobarista"
+	"github.com/choice404/bobarista/bobaristatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBranchingFlow(completed *bool) *bobarista.BobaBuilder {
+	var name, userType, company string
+
+	return bobarista.New("Driver Test").
+		AddForm(bobarista.NewForm("name", "Name").
+			WithWrites("name").
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewInput().Title("Name").Value(&name),
+				))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("name", name)
+				return nil
+			})).
+		AddForm(bobarista.NewForm("type", "User Type").
+			WithWrites("user_type").
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewSelect[string]().
+						Title("Type").
+						Options(
+							huh.NewOption("Individual", "individual"),
+							huh.NewOption("Company", "company"),
+						).
+						Value(&userType),
+				))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("user_type", userType)
+				return nil
+			})).
+		AddForm(bobarista.NewForm("company", "Company").
+			WithReads("user_type").
+			WithWrites("company_name").
+			WithSkipCondition(func(current *bobarista.FormData, global *bobarista.FormData) bool {
+				val, _ := global.Values.Get("user_type")
+				return val != "company"
+			}).
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewInput().Title("Company").Value(&company),
+				))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("company_name", company)
+				return nil
+			})).
+		OnComplete(func(boba *bobarista.Bobarista) error {
+			*completed = true
+			return nil
+		})
+}
+
+func TestDriverIndividualPath(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).Build()).Start()
+
+	d.AssertFormID("name")
+	d.AssertState(bobarista.StateActive)
+	d.AssertViewContains("Driver Test - Name")
+
+	d.Type("Jane").Submit()
+	d.AssertValue("name", "Jane")
+	d.AssertFormID("type")
+
+	d.Submit()
+	d.AssertValue("user_type", "individual")
+	d.AssertState(bobarista.StateCompleted)
+	d.AssertNoValue("company_name")
+	d.AssertViewContains("Completed")
+
+	reason, skipped := d.Flow().GetSkipReason("company")
+	assert.True(t, skipped)
+	assert.Equal(t, `user_type = "individual"`, reason)
+
+	d.Submit()
+	assert.True(t, completed)
+	assert.True(t, d.Quit())
+	assert.NotEmpty(t, d.Frames())
+}
+
+func TestDriverCompanyPath(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).Build()).Start()
+
+	d.Type("Jane").Submit()
+	d.Press("down").Submit()
+	d.AssertValue("user_type", "company")
+	d.AssertFormID("company")
+
+	d.Type("Acme").Submit()
+	d.AssertValue("company_name", "Acme")
+	d.AssertState(bobarista.StateCompleted)
+
+	owner, exists := d.Flow().GetValueOwner("company_name")
+	assert.True(t, exists)
+	assert.Equal(t, "company", owner)
+}
+
+func TestDriverSwitchToIndividual(t *testing.T) {
+	confirm := bobarista.NewForm("confirm", "Confirm").
+		WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+			var ok bool
+			return huh.NewForm(huh.NewGroup(huh.NewConfirm().Title("Done?").Value(&ok)))
+		})
+
+	for _, prefilled := range []bool{false, true} {
+		var completed bool
+		builder := newBranchingFlow(&completed).WithDebug(true).AddForm(confirm)
+		if prefilled {
+			builder.WithDefaults(map[string]string{"company_name": "Acme"})
+		}
+		d := bobaristatest.NewDriver(t, builder.Build(), bobaristatest.WithSize(140, 40)).Start()
+
+		d.Type("Jane").Submit()
+		d.Press("down").Submit()
+		d.Type("Acme").Submit()
+		d.AssertFormID("confirm")
+		d.AssertValue("company_name", "Acme")
+
+		d.Press("f2", "tab", "down", "enter", "f2")
+		d.AssertFormID("type")
+		d.Press("up").Submit()
+		d.AssertValue("user_type", "individual")
+		d.AssertFormID("confirm")
+		d.AssertNoValue("company_name")
+
+		d.Submit()
+		d.AssertState(bobarista.StateCompleted)
+		assert.NotContains(t, d.View(), "Acme", "prefilled: %t", prefilled)
+		assert.False(t, d.Flow().GetGlobalData().Values.Has("company_name"), "prefilled: %t", prefilled)
+	}
+}
+
+func TestSavedAnswers(t *testing.T) {
+	answersFile := filepath.Join(t.TempDir(), "answers.json")
+
+	var name, token string
+	boba := bobarista.New("Saved Answers").
+		WithPreviousAnswers(answersFile).
+		AddForm(bobarista.NewForm("info", "Info").
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(huh.NewInput().Title("Name").Value(&name)))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("name", name)
+				return nil
+			})).
+		AddForm(bobarista.NewForm("token", "Token").
+			WithSensitive().
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(huh.NewInput().Title("Token").Value(&token)))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("token", token)
+				return nil
+			})).
+		Build()
+
+	d := bobaristatest.NewDriver(t, boba).Start()
+	d.Type("Jane").Submit()
+	d.Type("s3cret").Submit()
+	d.AssertValue("token", "s3cret")
+	d.Submit()
+	assert.True(t, d.Quit())
+
+	stored, err := bobarista.LoadAnswers(answersFile)
+	assert.NoError(t, err)
+	storedName, _ := stored.Get("name")
+	assert.Equal(t, "Jane", storedName)
+	assert.False(t, stored.Has("token"))
+
+	info, err := os.Stat(answersFile)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+}
+
+func TestDriverQuit(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).Build()).Start()
+
+	d.Press("ctrl+c")
+	assert.True(t, d.Quit())
+	assert.False(t, completed)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	recordFile := filepath.Join(t.TempDir(), "session.jsonl")
+
+	var completed bool
+	recorded := newBranchingFlow(&completed).WithRecorder(recordFile).Build()
+
+	d := bobaristatest.NewDriver(t, recorded).Start()
+	d.Type("Jane").Submit()
+	d.Press("down").Submit()
+	d.Type("Acme").Submit()
+	d.AssertState(bobarista.StateCompleted)
+
+	info, err := os.Stat(recordFile)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	recording, err := bobarista.LoadRecording(recordFile)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, recording.Messages())
+	assert.Len(t, recording.Navigation(), 3)
+
+	replayed := bobaristatest.NewDriver(t, newBranchingFlow(&completed).Build()).Replay(recordFile)
+	replayed.AssertState(bobarista.StateCompleted)
+	replayed.AssertValue("name", "Jane")
+	replayed.AssertValue("company_name", "Acme")
+	assert.Equal(t, d.View(), replayed.View())
+
+	before, err := os.ReadFile(recordFile)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, recorded.ValidateReplay(recordFile), bobarista.ErrReplayingRecordFile)
+	assert.ErrorIs(t, recorded.Replay(recordFile, 0), bobarista.ErrReplayingRecordFile)
+	after, err := os.ReadFile(recordFile)
+	assert.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	var password string
+	secretFile := filepath.Join(t.TempDir(), "secret.jsonl")
+	secret := bobarista.New("Login").
+		WithRecorder(secretFile).
+		AddForm(bobarista.NewForm("login", "Login").
+			WithSensitive().
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewInput().Title("Password").EchoMode(huh.EchoModePassword).Value(&password),
+				))
+			})).
+		Build()
+	bobaristatest.NewDriver(t, secret).Start().Type("hunter2").Submit()
+	assert.Equal(t, "hunter2", password)
+
+	data, err := os.ReadFile(secretFile)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter")
+	assert.Contains(t, string(data), `"runes":"*"`)
+}
+
+func TestDriverLocale(t *testing.T) {
+	var completed bool
+	boba := newBranchingFlow(&completed).
+		WithLocale("de_DE").
+		WithMessage(bobarista.KeyLabel("name"), "Vorname").
+		Build()
+	d := bobaristatest.NewDriver(t, boba).Start()
+
+	d.AssertViewContains("ctrl+c beenden")
+
+	d.Type("Jane").Submit()
+	d.Submit()
+	d.AssertState(bobarista.StateCompleted)
+	d.AssertViewContains("Driver Test - Abgeschlossen")
+	d.AssertViewContains("Alle Werte:")
+	d.AssertViewContains("Vorname: Jane")
+	d.AssertViewContains("User Type: individual")
+
+	japanese := bobaristatest.NewDriver(t, newBranchingFlow(&completed).WithLocale("ja").Build()).Start()
+	japanese.AssertViewContains("ctrl+c 終了")
+}
+
+func TestDriverQuitConfirmation(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).WithQuitConfirmation(true).Build()).Start()
+
+	d.Press("esc")
+	d.AssertState(bobarista.StateActive)
+	assert.NotContains(t, d.View(), "Quit?")
+
+	d.Press("ctrl+c")
+	d.AssertState(bobarista.StateActive)
+	d.AssertViewContains("Quit? Your answers will be lost.")
+	d.AssertViewContains("y yes • n no")
+
+	d.Press("n")
+	d.AssertState(bobarista.StateActive)
+	d.AssertViewContains("ctrl+c quit")
+
+	d.Type("Jane").Submit()
+	d.AssertValue("name", "Jane")
+
+	d.Press("ctrl+c", "y")
+	assert.True(t, d.Quit())
+	assert.False(t, completed)
+}
+
+func TestDriverCompactFooter(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).
+		WithQuitConfirmation(true).
+		WithTimeout(time.Minute, bobarista.TimeoutAbort).
+		Build(), bobaristatest.WithSize(60, 12)).Start()
+	assert.NotContains(t, d.View(), "ctrl+c quit")
+
+	d.Type("J")
+	d.AssertViewContains("⏱ 1:00 left")
+	assert.NotContains(t, d.View(), "ctrl+c quit")
+
+	d.Press("ctrl+c")
+	d.AssertViewContains("Quit? Your answers will be lost. • ⏱ 1:00 left")
+	assert.NotContains(t, d.View(), "y yes")
+
+	d.Press("y")
+	assert.True(t, d.Quit())
+}
+
+func TestDriverCustomKeyMap(t *testing.T) {
+	keys := bobarista.DefaultKeyMap()
+	keys.Quit = key.NewBinding(key.WithKeys("ctrl+q"), key.WithHelp("ctrl+q", "leave"))
+	keys.Finish = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", ""))
+
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).WithKeyMap(keys).Build()).Start()
+	d.AssertViewContains("ctrl+q leave")
+
+	d.Press("esc")
+	d.AssertState(bobarista.StateActive)
+
+	d.Type("Jane").Submit()
+	d.Submit()
+	d.AssertState(bobarista.StateCompleted)
+	d.AssertViewContains("f finish • q quit")
+
+	d.Press("enter")
+	assert.False(t, completed)
+	d.Press("f")
+	assert.True(t, completed)
+	assert.True(t, d.Quit())
+}
+
+func TestDriverInspector(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).WithDebug(true).Build(),
+		bobaristatest.WithSize(140, 40)).Start()
+
+	d.Type("Jane").Submit()
+	d.AssertFormID("type")
+
+	d.Press("f2")
+	assert.True(t, d.Flow().IsInspecting())
+	d.AssertViewContains("INSPECTOR")
+	d.AssertViewContains("[Values]")
+	d.AssertViewContains("name: Jane")
+
+	d.Press("enter", "ctrl+u").Type("Joan").Press("enter")
+	d.AssertValue("name", "Joan")
+
+	d.Press("tab", "r")
+	d.AssertViewContains("[Forms]")
+	d.AssertViewContains("company Company skip")
+	d.AssertViewContains("type User Type show (current)")
+
+	d.Press("down", "down", "enter")
+	d.AssertFormID("company")
+
+	d.Press("tab")
+	d.AssertViewContains("1. name")
+	d.AssertViewContains("3. company (current)")
+
+	d.Press("tab")
+	d.AssertViewContains("NavigationDecided type → company")
+
+	d.Press("f2")
+	assert.False(t, d.Flow().IsInspecting())
+
+	d.Type("Acme").Submit()
+	d.AssertState(bobarista.StateCompleted)
+	d.AssertValue("company_name", "Acme")
+
+	d = bobaristatest.NewDriver(t, newBranchingFlow(&completed).WithDebug(true).Build(),
+		bobaristatest.WithSize(140, 40)).Start()
+	d.Type("Jane").Submit()
+	d.Press("f2", "enter", "esc")
+	assert.True(t, d.Flow().IsInspecting())
+	assert.False(t, d.Quit())
+
+	d.Press("enter", "ctrl+c")
+	assert.True(t, d.Quit())
+	d.AssertValue("name", "Jane")
+}
+
+func TestDriverDerivedValues(t *testing.T) {
+	var completed bool
+	boba := newBranchingFlow(&completed).
+		WithDebug(true).
+		WithDerived("greeting", []string{"name", "company_name"}, func(values *bobarista.FormValues) string {
+			name, _ := values.Get("name")
+			if company, exists := values.Get("company_name"); exists {
+				return "Hello " + name + " from " + company
+			}
+			return "Hello " + name
+		}).
+		Build()
+	d := bobaristatest.NewDriver(t, boba, bobaristatest.WithSize(140, 40)).Start()
+
+	d.AssertNoValue("greeting")
+	d.Type("Jane").Submit()
+	d.AssertValue("greeting", "Hello Jane")
+	assert.True(t, boba.IsDerived("greeting"))
+	assert.Empty(t, boba.GetDiagnostics())
+
+	d.Press("f2")
+	d.AssertViewContains("greeting: Hello Jane (derived, read-only)")
+	d.Press("enter")
+	assert.NotContains(t, d.View(), "= Hello Jane")
+	d.Press("f2")
+
+	d.Press("down").Submit()
+	d.Type("Acme").Submit()
+	d.AssertValue("greeting", "Hello Jane from Acme")
+	d.AssertState(bobarista.StateCompleted)
+	d.AssertViewContains("Hello Jane from Acme")
+}
+
+func TestDriverSnapshots(t *testing.T) {
+	var completed bool
+	boba := newBranchingFlow(&completed).Build()
+	assert.ErrorIs(t, boba.SendValue("user_type", "company"), bobarista.ErrNotRunning)
+
+	done := make(chan struct{})
+	reads := make(chan int)
+	go func() {
+		count := 0
+		for {
+			select {
+			case <-done:
+				reads <- count
+				return
+			default:
+				snapshot := boba.GetSnapshot()
+				snapshot.Global.Set("scratch", "ignored")
+				count++
+			}
+		}
+	}()
+
+	d := bobaristatest.NewDriver(t, boba).Start()
+	d.Type("Jane").Submit()
+
+	snapshot := boba.GetSnapshot()
+	assert.Equal(t, "type", snapshot.FormID)
+	assert.Equal(t, bobarista.StateActive, snapshot.State)
+	name, _ := snapshot.Global.Get("name")
+	assert.Equal(t, "Jane", name)
+	assert.False(t, snapshot.Global.Has("scratch"))
+
+	snapshot.Global.Set("name", "Changed")
+	value, _ := boba.GetSnapshotValue("name")
+	assert.Equal(t, "Jane", value)
+
+	d.Send(bobarista.ValueUpdateMsg{Values: map[string]string{"referrer": "newsletter"}, Delete: []string{"name"}})
+	d.AssertValue("referrer", "newsletter")
+	d.AssertNoValue("name")
+	_, exists := boba.GetSnapshotValue("name")
+	assert.False(t, exists)
+
+	d.Press("down").Submit()
+	d.Type("Acme").Submit()
+	close(done)
+	assert.Positive(t, <-reads)
+
+	snapshot = boba.GetSnapshot()
+	assert.Equal(t, bobarista.StateCompleted, snapshot.State)
+	assert.Contains(t, snapshot.Forms, "company")
+	referrer, _ := snapshot.Global.Get("referrer")
+	assert.Equal(t, "newsletter", referrer)
+}
+
+func TestDriverTimeouts(t *testing.T) {
+	var completed bool
+	d := bobaristatest.NewDriver(t, newBranchingFlow(&completed).
+		WithTimeout(time.Minute, bobarista.TimeoutAbort).
+		Build()).Start()
+	assert.NotContains(t, d.View(), "⏱")
+	d.Type("J")
+	d.AssertViewContains("⏱ 1:00 left")
+
+	var timedOut []string
+	d = bobaristatest.NewDriver(t, newBranchingFlow(&completed).
+		WithTimeout(300*time.Millisecond, bobarista.TimeoutAbort).
+		OnTimeout(func(boba *bobarista.Bobarista, current *bobarista.FormData) error {
+			timedOut = append(timedOut, current.ID)
+			return nil
+		}).
+		Build()).Start()
+	d.Type("J")
+	time.Sleep(350 * time.Millisecond)
+	d.Press("a")
+	assert.True(t, d.Quit())
+	assert.Equal(t, []string{"name"}, timedOut)
+	if errs := d.Flow().GetErrors(); assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], bobarista.ErrTimeout)
+	}
+
+	d = bobaristatest.NewDriver(t, newBranchingFlow(&completed).
+		WithTimeout(300*time.Millisecond, bobarista.TimeoutSubmit).
+		Build()).Start()
+	d.Type("J")
+	time.Sleep(350 * time.Millisecond)
+	d.Press("a")
+	d.AssertState(bobarista.StateCompleted)
+	d.AssertValue("name", "J")
+	d.AssertValue("user_type", "individual")
+
+	var confirmed string
+	d = bobaristatest.NewDriver(t, newBranchingFlow(&completed).
+		WithTimeoutAction(bobarista.TimeoutSubmit).
+		AddForm(bobarista.NewForm("confirm", "Confirm").
+			WithTimeout(300*time.Millisecond).
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewSelect[string]().
+						Options(huh.NewOption("Yes", "yes"), huh.NewOption("No", "no")).
+						Value(&confirmed),
+				))
+			})).
+		Build()).Start()
+	d.Type("Jane").Submit()
+	d.Submit()
+	d.AssertFormID("confirm")
+	time.Sleep(350 * time.Millisecond)
+	d.Press("down")
+	d.AssertState(bobarista.StateCompleted)
+	assert.Equal(t, "yes", confirmed)
+
+	var inits, starts int
+	boba := newBranchingFlow(&completed).
+		WithTimeout(500*time.Millisecond, bobarista.TimeoutReset).
+		WithDefaults(map[string]string{"region": "eu"}).
+		OnInit(func(boba *bobarista.Bobarista, forms []bobarista.FormData) {
+			inits++
+		}).
+		Build()
+	boba.Subscribe(func(event bobarista.Event) {
+		if event.Type == bobarista.EventFlowStarted {
+			starts++
+		}
+	})
+	d = bobaristatest.NewDriver(t, boba).Start()
+	d.Type("Jane").Submit()
+	d.AssertFormID("type")
+	time.Sleep(550 * time.Millisecond)
+	d.Press("down")
+	d.AssertFormID("name")
+	d.AssertState(bobarista.StateActive)
+	d.AssertNoValue("name")
+	d.AssertValue("region", "eu")
+	assert.NotContains(t, d.View(), "⏱")
+	assert.Equal(t, 1, inits)
+	assert.Equal(t, 1, starts)
+
+	d = bobaristatest.NewDriver(t, newBranchingFlow(&completed).
+		AddForm(bobarista.NewForm("notes", "Notes").
+			WithTimeout(300*time.Millisecond).
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				var notes string
+				return huh.NewForm(huh.NewGroup(huh.NewInput().Value(&notes)))
+			})).
+		Build()).Start()
+	d.Type("Jane").Submit()
+	d.Submit()
+	d.AssertFormID("notes")
+	for range 3 {
+		time.Sleep(200 * time.Millisecond)
+		d.Type("a")
+	}
+	d.AssertState(bobarista.StateActive)
+	time.Sleep(350 * time.Millisecond)
+	d.Type("b")
+	assert.True(t, d.Quit())
+	if errs := d.Flow().GetErrors(); assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], bobarista.ErrTimeout)
+	}
+}
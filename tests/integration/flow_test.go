@@ -1,15 +1,24 @@
 package integration
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
-	"github.com/choice404/bobarista/pkg/bobarista"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/choice404/bobarista"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -157,6 +166,183 @@ func TestNavigator(t *testing.T) {
 	assert.False(t, navigator.HasPrevious())
 }
 
+func TestNavigationTargets(t *testing.T) {
+	gen := func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+		var val string
+		return huh.NewForm(huh.NewGroup(huh.NewInput().Value(&val)))
+	}
+	forms := []bobarista.Form{
+		bobarista.NewForm("form1", "Form 1").WithGenerator(gen).WithNavigationTargets("form2"),
+		bobarista.NewForm("form2", "Form 2").WithGenerator(gen),
+	}
+	assert.Empty(t, bobarista.NewNavigator(forms).ValidateNavigation())
+
+	forms[0] = forms[0].WithNavigationTargets("form2", "missing")
+	errors := bobarista.NewNavigator(forms).ValidateNavigation()
+	if assert.Len(t, errors, 1) {
+		assert.ErrorIs(t, errors[0], bobarista.ErrUnknownNavigationTarget)
+	}
+}
+
+func TestFlowAnalysis(t *testing.T) {
+	gen := func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+		var val string
+		return huh.NewForm(huh.NewGroup(huh.NewInput().Value(&val)))
+	}
+
+	forms := []bobarista.Form{
+		bobarista.NewForm("plan", "Plan").WithGenerator(gen).
+			WithReads("region", "seats").
+			WithWrites("plan", "coupon"),
+		bobarista.NewForm("seats", "Seats").WithGenerator(gen).
+			WithReads("plan").
+			WithWrites("seats").
+			WithNavigationTargets("plan"),
+		bobarista.NewForm("review", "Review").WithGenerator(gen).
+			WithReads("plan", "seats"),
+	}
+
+	diagnostics := bobarista.NewNavigator(forms).Analyze([]string{"region"}, nil)
+	if assert.Len(t, diagnostics, 2) {
+		assert.ErrorIs(t, diagnostics[0], bobarista.ErrUnusedWrite)
+		assert.Equal(t, "plan", diagnostics[0].FormID)
+		assert.Equal(t, "coupon", diagnostics[0].Key)
+		assert.Equal(t, bobarista.SeverityWarning, diagnostics[0].Severity)
+
+		assert.ErrorIs(t, diagnostics[1], bobarista.ErrNavigationCycle)
+		assert.Equal(t, []string{"plan", "seats", "plan"}, diagnostics[1].Path)
+	}
+
+	forms[1] = forms[1].WithNavigationTargets()
+	diagnostics = bobarista.NewNavigator(forms).Analyze(nil, []string{"coupon"})
+	if assert.Len(t, diagnostics, 2) {
+		assert.ErrorIs(t, diagnostics[0], bobarista.ErrUnwrittenRead)
+		assert.Equal(t, "region", diagnostics[0].Key)
+		assert.ErrorIs(t, diagnostics[1], bobarista.ErrUnwrittenRead)
+		assert.Equal(t, "seats", diagnostics[1].Key)
+		assert.Equal(t, bobarista.SeverityError, diagnostics[1].Severity)
+	}
+
+	builder := bobarista.New("Analysis").
+		WithDefaults(map[string]string{"region": "eu"}).
+		WithStrictAnalysis(true)
+	for _, form := range forms {
+		builder.AddForm(form)
+	}
+	boba := builder.Build()
+	assert.Len(t, boba.GetDiagnostics(), 2)
+	assert.Equal(t, bobarista.StateError, boba.GetState())
+	assert.ErrorIs(t, boba.GetErrors()[0], bobarista.ErrUnwrittenRead)
+}
+
+func TestExpressions(t *testing.T) {
+	values := bobarista.NewFormValues()
+	values.Set("user_type", "company")
+	values.Set("seats", "12")
+	values.Set("email", "jane@example.com")
+	values.Set("newsletter", "false")
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"user_type == 'company'", true},
+		{`user_type != "company"`, false},
+		{"seats > 9", true},
+		{"seats >= 12 and seats < 100", true},
+		{"seats == 12.0", true},
+		{"user_type in ['individual', 'company']", true},
+		{"user_type not in ['company']", false},
+		{`email =~ '^[^@]+@example\.com$'`, true},
+		{"email !~ 'example'", false},
+		{"empty(company_name)", true},
+		{"not empty(email) && !newsletter", true},
+		{"newsletter or (user_type == 'company' and seats > 100)", false},
+		{"company_name", false},
+		{`global.user_type != "company"`, false},
+		{"global.seats > 9 and user_type == global.user_type", true},
+	}
+	for _, tt := range tests {
+		expr, err := bobarista.ParseExpr(tt.expr)
+		if assert.NoError(t, err, tt.expr) {
+			assert.Equal(t, tt.want, expr.Eval(values), tt.expr)
+		}
+	}
+
+	expr, err := bobarista.ParseExpr("plan in ['pro'] or empty(seats) or plan == coupon")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"plan", "seats", "coupon"}, expr.Keys())
+
+	expr, err = bobarista.ParseExpr("global.plan == 'pro' or empty(global.plan)")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"plan"}, expr.Keys())
+
+	for _, invalid := range []string{"", "user_type ==", "a == 'b", "a =~ b", "a =~ '('", "(a", "a in 'b'", "a # b", "global. == 'b'"} {
+		_, err := bobarista.ParseExpr(invalid)
+		assert.ErrorIs(t, err, bobarista.ErrInvalidExpression, invalid)
+	}
+
+	gen := func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+		var val string
+		return huh.NewForm(huh.NewGroup(huh.NewInput().Value(&val)))
+	}
+	forms := []bobarista.Form{
+		bobarista.NewForm("type", "Type").WithGenerator(gen).
+			WithNavigationRule("user_type == 'vip'", "review"),
+		bobarista.NewForm("company", "Company").WithGenerator(gen).
+			WithSkipWhen("user_type != 'company'"),
+		bobarista.NewForm("review", "Review").WithGenerator(gen),
+	}
+
+	navigator := bobarista.NewNavigator(forms)
+	assert.Empty(t, navigator.ValidateNavigation())
+	global := bobarista.NewFormData("global")
+	assert.NoError(t, navigator.MoveToFirstValid(global))
+
+	global.Values.Set("user_type", "vip")
+	next, err := navigator.Next(global)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, next)
+
+	global.Values.Set("user_type", "individual")
+	next, err = navigator.Next(global)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, next)
+	assert.Equal(t, []int{1}, navigator.LastSkipped())
+
+	global.Values.Set("user_type", "company")
+	next, err = navigator.Next(global)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, next)
+
+	diagnostics := navigator.Analyze(nil, nil)
+	if assert.Len(t, diagnostics, 2) {
+		assert.ErrorIs(t, diagnostics[0], bobarista.ErrUnwrittenRead)
+		assert.Equal(t, "type", diagnostics[0].FormID)
+		assert.Equal(t, "company", diagnostics[1].FormID)
+	}
+
+	replaced := forms[1].WithSkipCondition(func(current *bobarista.FormData, global *bobarista.FormData) bool {
+		return false
+	})
+	assert.Nil(t, replaced.SkipWhen)
+	diagnostics = bobarista.NewNavigator([]bobarista.Form{forms[0], replaced, forms[2]}).Analyze(nil, nil)
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, "type", diagnostics[0].FormID)
+	}
+
+	forms[2] = forms[2].WithSkipWhen("user_type ==")
+	errs := bobarista.NewNavigator(forms).ValidateNavigation()
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], bobarista.ErrInvalidExpression)
+	}
+	builder := bobarista.New("Expressions")
+	for _, form := range forms {
+		builder.AddForm(form)
+	}
+	assert.Equal(t, bobarista.StateError, builder.Build().GetState())
+}
+
 func TestErrorHandling(t *testing.T) {
 
 	err := bobarista.NewCupSleeveError("test-form", assert.AnError)
@@ -380,3 +566,648 @@ func TestOnCompleteWithFixtures(t *testing.T) {
 
 	assert.NotNil(t, boba)
 }
+
+func TestFormLoader(t *testing.T) {
+	var region string
+	var received any
+
+	boba := bobarista.New("Loader Test").
+		AddForm(bobarista.NewForm("region", "Region").
+			WithLoader(
+				func(current *bobarista.FormValues, global *bobarista.FormValues) tea.Cmd {
+					return func() tea.Msg {
+						return []string{"us-east", "eu-west"}
+					}
+				},
+				func(current *bobarista.FormValues, global *bobarista.FormValues, data any) *huh.Form {
+					received = data
+					return huh.NewForm(
+						huh.NewGroup(
+							huh.NewSelect[string]().Title("Region").
+								Options(huh.NewOptions(data.([]string)...)...).
+								Value(&region),
+						),
+					)
+				}).
+			WithLoadTimeout(time.Second)).
+		Build()
+
+	cmd := boba.Init()
+	assert.NotNil(t, cmd)
+	assert.True(t, boba.IsLoading())
+
+	batch, ok := cmd().(tea.BatchMsg)
+	assert.True(t, ok)
+	assert.NotEmpty(t, batch)
+
+	boba.Update(batch[0]())
+	assert.False(t, boba.IsLoading())
+	assert.Equal(t, []string{"us-east", "eu-west"}, received)
+	assert.Empty(t, boba.GetErrors())
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	var events []string
+	generator := func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+		var val string
+		return huh.NewForm(huh.NewGroup(huh.NewInput().Value(&val)))
+	}
+
+	boba := bobarista.New("Hooks Test").
+		AddForm(bobarista.NewForm("company", "Company").
+			WithGenerator(generator).
+			WithSkipCondition(func(current *bobarista.FormData, global *bobarista.FormData) bool {
+				return true
+			}).
+			WithOnSkip(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Delete("company_name")
+				events = append(events, "skip:"+current.ID)
+				return nil
+			})).
+		AddForm(bobarista.NewForm("personal", "Personal").
+			WithGenerator(generator).
+			WithOnEnter(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				current.Values.Set("name", "prefilled")
+				events = append(events, "enter:"+current.ID)
+				return nil
+			})).
+		OnFormEnter(func(boba *bobarista.Bobarista, current *bobarista.FormData) error {
+			events = append(events, "flow-enter:"+current.ID)
+			return nil
+		}).
+		OnFormSkip(func(boba *bobarista.Bobarista, current *bobarista.FormData) error {
+			events = append(events, "flow-skip:"+current.ID)
+			return nil
+		}).
+		Build()
+
+	boba.GetGlobalData().Values.Set("company_name", "Stale Inc")
+	boba.Init()
+
+	assert.Equal(t, []string{"skip:company", "flow-skip:company", "enter:personal", "flow-enter:personal"}, events)
+	assert.False(t, boba.GetGlobalData().Values.Has("company_name"))
+
+	name, _ := boba.GetCurrentFormData().Values.Get("name")
+	assert.Equal(t, "prefilled", name)
+}
+
+func TestEventSubscription(t *testing.T) {
+	generator := func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+		var val string
+		return huh.NewForm(huh.NewGroup(huh.NewInput().Value(&val)))
+	}
+
+	boba := bobarista.New("Events Test").
+		AddForm(bobarista.NewForm("skipped", "Skipped").
+			WithGenerator(generator).
+			WithSkipCondition(func(current *bobarista.FormData, global *bobarista.FormData) bool {
+				return true
+			})).
+		AddForm(bobarista.NewForm("main", "Main").WithGenerator(generator)).
+		Build()
+
+	var events []bobarista.Event
+	unsubscribe := boba.Subscribe(func(e bobarista.Event) {
+		events = append(events, e)
+	})
+
+	boba.Init()
+
+	var types []bobarista.EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+		assert.False(t, e.Time.IsZero())
+	}
+	assert.Equal(t, []bobarista.EventType{
+		bobarista.EventFlowStarted,
+		bobarista.EventFormSkipped,
+		bobarista.EventFormEntered,
+	}, types)
+	assert.Equal(t, "skipped", events[1].FormID)
+	assert.Equal(t, "main", events[2].FormID)
+
+	unsubscribe()
+	boba.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.Len(t, events, 3)
+}
+
+func TestPrefill(t *testing.T) {
+	answersFile := filepath.Join(t.TempDir(), "answers.json")
+	err := bobarista.SaveAnswers(answersFile, bobarista.FormValues{
+		"name":  nil,
+		"email": func() *string { s := "old@example.com"; return &s }(),
+	})
+	assert.NoError(t, err)
+
+	t.Setenv("BOBATEST_REGION", "eu-west")
+
+	var name string
+	boba := bobarista.New("Prefill Test").
+		WithDefaults(map[string]string{"name": "Jane", "email": "jane@example.com", "region": "us-east"}).
+		WithPreviousAnswers(answersFile).
+		WithEnvPrefix("BOBATEST_").
+		AddForm(bobarista.NewForm("info", "Info").
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewInput().Title("Name").Value(global.Prefill("name", &name)),
+				))
+			})).
+		Build()
+
+	boba.Init()
+	assert.Empty(t, boba.GetErrors())
+
+	values := boba.GetGlobalData().Values
+	email, _ := values.Get("email")
+	region, _ := values.Get("region")
+	assert.Equal(t, "old@example.com", email)
+	assert.Equal(t, "eu-west", region)
+	assert.Equal(t, "Jane", name)
+}
+
+func TestAnswerMigration(t *testing.T) {
+	answersFile := filepath.Join(t.TempDir(), "answers.json")
+	assert.NoError(t, bobarista.SaveAnswers(answersFile, bobarista.FormValues{
+		"fullname": func() *string { s := "Jane Doe"; return &s }(),
+		"fax":      func() *string { s := "555-0100"; return &s }(),
+	}))
+
+	newFlow := func(version int) *bobarista.Bobarista {
+		var name string
+		return bobarista.New("Migration Test").
+			WithVersion(version).
+			WithMigration(0, bobarista.RenameKey("fullname", "name")).
+			WithMigration(1, bobarista.DropKeys("fax")).
+			WithMigration(2, func(values *bobarista.FormValues) error {
+				name, _ := values.Get("name")
+				values.Set("initials", name[:1])
+				return nil
+			}).
+			WithPreviousAnswers(answersFile).
+			AddForm(bobarista.NewForm("info", "Info").
+				WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+					return huh.NewForm(huh.NewGroup(
+						huh.NewInput().Title("Name").Value(global.Prefill("name", &name)),
+					))
+				})).
+			Build()
+	}
+
+	boba := newFlow(3)
+	boba.Init()
+	assert.Empty(t, boba.GetErrors())
+
+	values := boba.GetGlobalData().Values
+	name, _ := values.Get("name")
+	initials, _ := values.Get("initials")
+	assert.Equal(t, "Jane Doe", name)
+	assert.Equal(t, "J", initials)
+	assert.False(t, values.Has("fullname"))
+	assert.False(t, values.Has("fax"))
+
+	assert.NoError(t, bobarista.SaveVersionedAnswers(answersFile, 3, *values))
+	stored, version, err := bobarista.LoadVersionedAnswers(answersFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.False(t, stored.Has(bobarista.AnswersVersionKey))
+
+	older := newFlow(2)
+	older.Init()
+	if assert.Len(t, older.GetErrors(), 1) {
+		assert.ErrorIs(t, older.GetErrors()[0], bobarista.ErrUnsupportedVersion)
+	}
+}
+
+func TestThemeFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	toml := `name = "Harbor"
+primary = { light = "#005F87", dark = "#5FAFD7" }
+secondary = { light = "#00875F", dark = "#5FD7AF" }
+tertiary = { dark = "#AF87FF" }
+success = { light = "#00AF00" }
+error = { light = "#D70000", dark = "#FF5F5F" }
+warning = { light = "#D78700", dark = "#FFAF00" }
+info = { light = "#0087D7", dark = "#5FAFFF" }
+`
+	yaml := `primary: {light: "#111111"}
+secondary: {light: "#222222"}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "harbor.toml"), []byte(toml), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte(yaml), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	names, err := bobarista.LoadColorSchemes(dir)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err.(*bobarista.ErrorCollector).Errors()[0], bobarista.ErrMissingThemeColor)
+	assert.Equal(t, []string{"harbor"}, names)
+
+	scheme, exists := bobarista.GetColorScheme("harbor")
+	assert.True(t, exists)
+	assert.Equal(t, "Harbor", scheme.Name)
+	assert.Equal(t, "#AF87FF", scheme.Tertiary.Light)
+	assert.Equal(t, "#00AF00", scheme.Success.Dark)
+
+	json := `{"primary": {"light": "#000000", "dark": "#FFFFFF"}, "secondary": {"dark": "#1"},
+		"tertiary": {"dark": "#2"}, "success": {"dark": "#3"}, "error": {"dark": "#4"},
+		"warning": {"dark": "#5"}, "info": {"dark": "#6"}}`
+	scheme, err = bobarista.ParseColorScheme([]byte(json), "json")
+	assert.NoError(t, err)
+	assert.Equal(t, "#FFFFFF", scheme.Primary.Dark)
+
+	t.Setenv("NO_COLOR", "1")
+	auto, exists := bobarista.GetColorScheme(bobarista.AutoColorScheme)
+	assert.True(t, exists)
+	assert.Equal(t, "Monochrome", auto.Name)
+
+	profile := lipgloss.ColorProfile()
+	defer lipgloss.SetColorProfile(profile)
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	var completed bool
+	colorless := newBranchingFlow(&completed).WithColorScheme(bobarista.AutoColorScheme).Build()
+	colorless.Init()
+	assert.Equal(t, termenv.TrueColor, lipgloss.ColorProfile())
+	assert.NotContains(t, colorless.View(), "\x1b[38;")
+
+	colored := newBranchingFlow(&completed).WithColorScheme("ocean").Build()
+	colored.Init()
+	assert.Contains(t, colored.View(), "\x1b[38;")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bobarista.LoadColorSchemes(dir)
+			bobarista.GetColorScheme("harbor")
+			bobarista.GetAvailableColorSchemes()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAccessibleMode(t *testing.T) {
+	var completed bool
+	input := iotest.OneByteReader(strings.NewReader("Jane\n2\nAcme\n"))
+	var output bytes.Buffer
+
+	boba := newBranchingFlow(&completed).
+		WithAccessible(true).
+		WithIO(input, &output).
+		Build()
+
+	assert.NoError(t, boba.Run())
+	assert.True(t, completed)
+	assert.Equal(t, bobarista.StateCompleted, boba.GetState())
+
+	text := output.String()
+	assert.Contains(t, text, "Step 1 of 3: Name")
+	assert.Contains(t, text, "Step 2 of 3: User Type")
+	assert.Contains(t, text, "Step 3 of 3: Company")
+	assert.Contains(t, text, "Driver Test - Completed")
+	assert.Contains(t, text, "Company Name: Acme")
+	assert.Contains(t, text, "Name: Jane")
+	assert.NotContains(t, text, "\x1b[")
+
+	saveErr := errors.New("disk full")
+	failing := newBranchingFlow(&completed).
+		WithAccessible(true).
+		WithIO(iotest.OneByteReader(strings.NewReader("Jane\n1\n")), io.Discard).
+		OnComplete(func(boba *bobarista.Bobarista) error {
+			return saveErr
+		}).
+		Build()
+	assert.ErrorIs(t, failing.Run(), saveErr)
+	assert.Equal(t, bobarista.StateError, failing.GetState())
+}
+
+func TestController(t *testing.T) {
+	start := func(completed *bool, handler bobarista.EventHandler) (*bobarista.Bobarista, *bobarista.Controller, *io.PipeWriter) {
+		input, writer := io.Pipe()
+		boba := newBranchingFlow(completed).WithIO(input, io.Discard).Build()
+		boba.Subscribe(handler)
+		controller, err := boba.Start()
+		assert.NoError(t, err)
+		return boba, controller, writer
+	}
+
+	var completed bool
+	boba, controller, writer := start(&completed, func(bobarista.Event) {})
+	defer writer.Close()
+
+	_, err := boba.Start()
+	assert.ErrorIs(t, err, bobarista.ErrAlreadyRunning)
+	assert.ErrorIs(t, controller.GoTo("missing"), bobarista.ErrFormNotFound)
+
+	assert.NoError(t, controller.SetValue("user_type", "company"))
+	assert.NoError(t, controller.GoTo("company"))
+	assert.NoError(t, controller.Complete())
+
+	result := controller.Wait()
+	assert.NoError(t, result.Err)
+	assert.True(t, result.Completed)
+	assert.True(t, completed)
+	assert.Equal(t, bobarista.StateCompleted, result.State)
+	assert.Equal(t, "company", result.FormID)
+	userType, _ := result.Global.Get("user_type")
+	assert.Equal(t, "company", userType)
+	assert.ErrorIs(t, controller.SetValue("user_type", "individual"), bobarista.ErrNotRunning)
+
+	completed = false
+	var aborted bool
+	_, controller, writer = start(&completed, func(event bobarista.Event) {
+		if event.Type == bobarista.EventFlowAborted {
+			aborted = true
+		}
+	})
+	defer writer.Close()
+
+	jobErr := errors.New("background job failed")
+	assert.NoError(t, controller.Abort(jobErr))
+
+	select {
+	case <-controller.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("flow did not exit after Abort")
+	}
+	result = controller.Wait()
+	assert.ErrorIs(t, result.Err, jobErr)
+	assert.False(t, result.Completed)
+	assert.False(t, completed)
+	assert.True(t, aborted)
+
+	completed = false
+	input, writer := io.Pipe()
+	accessible := newBranchingFlow(&completed).WithAccessible(true).WithIO(input, io.Discard).Build()
+	controller, err = accessible.Start()
+	assert.NoError(t, err)
+	_, err = accessible.Start()
+	assert.ErrorIs(t, err, bobarista.ErrAlreadyRunning)
+
+	_, err = writer.Write([]byte("Jane\n1\n"))
+	assert.NoError(t, err)
+	writer.Close()
+	result = controller.Wait()
+	assert.NoError(t, result.Err)
+	assert.True(t, result.Completed)
+	assert.True(t, completed)
+}
+
+// recordingLogger collects log messages from the program goroutine.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, message)
+}
+
+func (l *recordingLogger) LogError(err error)        { l.record("ERROR: " + err.Error()) }
+func (l *recordingLogger) LogInfo(message string)    { l.record(message) }
+func (l *recordingLogger) LogDebug(message string)   { l.record(message) }
+func (l *recordingLogger) LogWarning(message string) { l.record(message) }
+
+// waitFor blocks until a message containing text has been logged.
+func (l *recordingLogger) waitFor(t *testing.T, text string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		l.mu.Lock()
+		for _, message := range l.messages {
+			if strings.Contains(message, text) {
+				l.mu.Unlock()
+				return
+			}
+		}
+		l.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for log message %q", text)
+}
+
+func TestLauncher(t *testing.T) {
+	newPlanFlow := func(completed *bool) *bobarista.BobaBuilder {
+		var plan string
+		return bobarista.New("Plan").
+			AddForm(bobarista.NewForm("plan", "Plan").
+				WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+					return huh.NewForm(huh.NewGroup(
+						huh.NewSelect[string]().
+							Options(huh.NewOption("Free", "free"), huh.NewOption("Pro", "pro")).
+							Value(&plan),
+					))
+				}).
+				WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+					current.Values.Set("plan", plan)
+					return nil
+				})).
+			OnComplete(func(boba *bobarista.Bobarista) error {
+				*completed = true
+				return nil
+			})
+	}
+
+	var signedUp, upgraded bool
+	logger := &recordingLogger{}
+	input, writer := io.Pipe()
+	defer writer.Close()
+	launched := make(chan *bobarista.Controller, 2)
+
+	launcher := bobarista.NewLauncher("Tools").
+		WithColorScheme("ocean").
+		WithLogger(logger).
+		WithIO(input, io.Discard).
+		OnLaunch(func(name string, controller *bobarista.Controller) {
+			launched <- controller
+		}).
+		AddFlow("Signup", "Create an account", newPlanFlow(&signedUp)).
+		AddFlow("Upgrade", "", newPlanFlow(&upgraded))
+
+	launcher.Init()
+	launcher.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	assert.Contains(t, launcher.View(), "> Signup")
+	assert.Contains(t, launcher.View(), "Create an account")
+	assert.Contains(t, launcher.View(), "enter start")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- launcher.Run()
+	}()
+
+	write := func(keys string) {
+		_, err := io.WriteString(writer, keys)
+		assert.NoError(t, err)
+	}
+
+	write("\r")
+	logger.waitFor(t, "Launching flow 'Signup'")
+	signup := <-launched
+	assert.NoError(t, signup.SetValue("referrer", "newsletter"))
+	write("\r")
+	logger.waitFor(t, "Form 'plan' completed")
+	write("\r")
+	logger.waitFor(t, "Flow 'Signup' exited, finished: true")
+	result := signup.Wait()
+	assert.True(t, result.Completed)
+	referrer, _ := result.Global.Get("referrer")
+	assert.Equal(t, "newsletter", referrer)
+	assert.ErrorIs(t, signup.SetValue("referrer", "ad"), bobarista.ErrNotRunning)
+
+	write("\x1b[B\r")
+	logger.waitFor(t, "Launching flow 'Upgrade'")
+	upgrade := <-launched
+	write("\x03")
+	logger.waitFor(t, "Flow 'Upgrade' exited, finished: false")
+	assert.False(t, upgrade.Wait().Completed)
+
+	write("q")
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("launcher did not exit")
+	}
+	assert.True(t, signedUp)
+	assert.False(t, upgraded)
+	assert.Nil(t, launcher.GetActiveFlow())
+	assert.Equal(t, 1, launcher.GetSelected())
+	assert.Contains(t, launcher.View(), "Upgrade was not completed")
+	logger.waitFor(t, "Initializing Bobarista")
+
+	accessible := bobarista.NewLauncher("Tools").
+		AddFlow("Signup", "", newPlanFlow(&signedUp).WithAccessible(true))
+	assert.ErrorIs(t, accessible.Run(), bobarista.ErrAccessibleLauncher)
+}
+
+// openFiles counts the file descriptors of the test process open on path.
+func openFiles(t *testing.T, path string) int {
+	t.Helper()
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip("open files cannot be listed on this platform")
+	}
+	count := 0
+	for _, entry := range entries {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", entry.Name())); err == nil && target == path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestLauncherClosesRecording(t *testing.T) {
+	var completed bool
+	recordFile := filepath.Join(t.TempDir(), "session.jsonl")
+	launcher := bobarista.NewLauncher("Tools").
+		AddFlow("Signup", "", newBranchingFlow(&completed).WithRecorder(recordFile))
+	launcher.Init()
+
+	for range 2 {
+		launcher.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, 1, openFiles(t, recordFile))
+		_, exit := launcher.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+		launcher.Update(exit())
+		assert.Nil(t, launcher.GetActiveFlow())
+		assert.Equal(t, 0, openFiles(t, recordFile))
+	}
+}
+
+func TestLauncherStaleMessages(t *testing.T) {
+	var loads int
+	var received []any
+	lookup := bobarista.New("Lookup").
+		AddForm(bobarista.NewForm("lookup", "Lookup").
+			WithLoader(
+				func(current *bobarista.FormValues, global *bobarista.FormValues) tea.Cmd {
+					loads++
+					data := fmt.Sprintf("run %d", loads)
+					return func() tea.Msg { return data }
+				},
+				func(current *bobarista.FormValues, global *bobarista.FormValues, data any) *huh.Form {
+					received = append(received, data)
+					var choice string
+					return huh.NewForm(huh.NewGroup(
+						huh.NewSelect[string]().Options(huh.NewOption("OK", "ok")).Value(&choice),
+					))
+				}))
+
+	launcher := bobarista.NewLauncher("Tools").AddFlow("Lookup", "", lookup)
+	launcher.Init()
+
+	_, first := launcher.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	stale, ok := first().(tea.BatchMsg)
+	assert.True(t, ok)
+	_, exit := launcher.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	launcher.Update(exit())
+	assert.Nil(t, launcher.GetActiveFlow())
+
+	_, second := launcher.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	launcher.Update(stale[0]())
+	assert.True(t, launcher.GetActiveFlow().IsLoading())
+	assert.Empty(t, received)
+
+	current, ok := second().(tea.BatchMsg)
+	assert.True(t, ok)
+	launcher.Update(current[0]())
+	assert.False(t, launcher.GetActiveFlow().IsLoading())
+	assert.Equal(t, []any{"run 2"}, received)
+}
+
+func TestFormTheme(t *testing.T) {
+	scheme, _ := bobarista.GetColorScheme("ocean")
+	theme := scheme.HuhTheme()
+	assert.Equal(t, scheme.Primary, theme.Focused.Title.GetForeground())
+	assert.Equal(t, scheme.Error, theme.Focused.ErrorMessage.GetForeground())
+	assert.Equal(t, scheme.Error, theme.Focused.ErrorIndicator.GetForeground())
+	assert.Equal(t, scheme.Secondary, theme.Focused.SelectSelector.GetForeground())
+	assert.Equal(t, scheme.Secondary, theme.Focused.MultiSelectSelector.GetForeground())
+
+	profile := lipgloss.ColorProfile()
+	defer lipgloss.SetColorProfile(profile)
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	sequence := func(color lipgloss.TerminalColor) string {
+		adaptive := color.(lipgloss.AdaptiveColor)
+		hex := adaptive.Light
+		if lipgloss.HasDarkBackground() {
+			hex = adaptive.Dark
+		}
+		return termenv.TrueColor.Color(hex).Sequence(false)
+	}
+	custom := huh.ThemeBase()
+	custom.Focused.Title = custom.Focused.Title.Foreground(lipgloss.Color("#123456"))
+	customSequence := termenv.TrueColor.Color("#123456").Sequence(false)
+
+	newFlow := func(own *huh.Theme) *bobarista.BobaBuilder {
+		var name string
+		return bobarista.New("Theme").
+			WithColorScheme("ocean").
+			AddForm(bobarista.NewForm("name", "Name").
+				WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+					form := huh.NewForm(huh.NewGroup(
+						huh.NewInput().Title("Name").Value(&name),
+					))
+					if own != nil {
+						form = form.WithTheme(own)
+					}
+					return form
+				}))
+	}
+
+	themed := newFlow(nil).Build()
+	themed.Init()
+	assert.Contains(t, themed.View(), sequence(scheme.Primary)+"mName")
+
+	own := newFlow(custom).WithoutFormTheme().Build()
+	own.Init()
+	assert.Contains(t, own.View(), customSequence+"mName")
+
+	accessible := newFlow(nil).WithAccessible(true).Build()
+	accessible.Init()
+	assert.Contains(t, accessible.View(), "┃ Name")
+	assert.NotContains(t, accessible.View(), sequence(scheme.Primary)+"mName")
+}
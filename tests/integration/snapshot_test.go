@@ -0,0 +1,142 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/choice404/bobarista"
+	"github.com/choice404/bobarista/bobaristatest"
+)
+
+func newSnapshotFlow(debug bool, onComplete error) *bobarista.Bobarista {
+	var name string
+
+	return bobarista.New("Snapshot Test").
+		WithDebug(debug).
+		WithColorScheme("ocean").
+		AddForm(bobarista.NewForm("profile", "Profile").
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewInput().Title("Name").Value(&name),
+				))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("first_name", name)
+				return nil
+			})).
+		OnComplete(func(boba *bobarista.Bobarista) error {
+			return onComplete
+		}).
+		Build()
+}
+
+func TestSnapshots(t *testing.T) {
+	for _, size := range [][2]int{{80, 24}, {120, 30}} {
+		opt := bobaristatest.WithSize(size[0], size[1])
+
+		d := bobaristatest.NewDriver(t, newSnapshotFlow(false, nil), opt).Start()
+		d.AssertSnapshot("active")
+		d.Type("Jane").Submit()
+		d.AssertSnapshot("completed")
+
+		d = bobaristatest.NewDriver(t, newSnapshotFlow(true, nil), opt).Start()
+		d.AssertSnapshot("debug")
+
+		d = bobaristatest.NewDriver(t, newSnapshotFlow(false, errors.New("save failed")), opt).Start()
+		d.Type("Jane").Submit().Submit()
+		d.AssertSnapshot("error")
+	}
+}
+
+func TestResponsiveSnapshots(t *testing.T) {
+	d := bobaristatest.NewDriver(t, newSnapshotFlow(false, nil), bobaristatest.WithSize(60, 12)).Start()
+	d.AssertSnapshot("compact")
+
+	d.Resize(20, 6)
+	d.AssertSnapshot("too_small")
+
+	d.Resize(60, 12)
+	d.Type("Jane").Submit()
+	d.AssertSnapshot("compact_completed")
+}
+
+func TestStyleOverrideSnapshot(t *testing.T) {
+	var name string
+
+	boba := bobarista.New("Styled").
+		WithStyleOverride(func(s *bobarista.Styles) {
+			s.HeaderFiller = "-"
+			s.HeaderAlign = lipgloss.Center
+			s.SetBorder(lipgloss.NormalBorder())
+		}).
+		AddForm(bobarista.NewForm("profile", "Profile").
+			WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+				return huh.NewForm(huh.NewGroup(
+					huh.NewInput().Title("Name").Value(&name),
+				))
+			}).
+			WithOnComplete(func(current *bobarista.FormData, global *bobarista.FormData) error {
+				global.Values.Set("name", name)
+				return nil
+			})).
+		Build()
+
+	d := bobaristatest.NewDriver(t, boba).Start()
+	d.Type("Jane").Submit()
+	d.AssertSnapshot("styled_completed")
+}
+
+func TestDiagramSnapshots(t *testing.T) {
+	var completed bool
+	newFlow := func() *bobarista.Bobarista {
+		return newBranchingFlow(&completed).
+			AddForm(bobarista.NewForm("review", "Review \"All\"").
+				WithGroup("final").
+				WithNavigation(func(data *bobarista.FormData) int { return -1 }).
+				WithNavigationTargets("name").
+				WithGenerator(func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+					return huh.NewForm(huh.NewGroup(huh.NewConfirm().Title("Done?")))
+				})).
+			Build()
+	}
+
+	for _, format := range []bobarista.DiagramFormat{bobarista.DiagramDOT, bobarista.DiagramMermaid} {
+		diagram, err := newFlow().Diagram(format, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bobaristatest.AssertSnapshot(t, "diagram_"+string(format), diagram)
+
+		d := bobaristatest.NewDriver(t, newFlow()).Start()
+		d.Type("Jane").Submit()
+		d.Submit()
+		diagram, err = d.Flow().Diagram(format, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bobaristatest.AssertSnapshot(t, "diagram_path_"+string(format), diagram)
+	}
+
+	if _, err := newFlow().Diagram("svg", false); !errors.Is(err, bobarista.ErrUnknownDiagramFormat) {
+		t.Errorf("expected ErrUnknownDiagramFormat, got %v", err)
+	}
+}
+
+func TestDiagramMermaidIDCollision(t *testing.T) {
+	gen := func(current *bobarista.FormValues, global *bobarista.FormValues) *huh.Form {
+		return huh.NewForm(huh.NewGroup(huh.NewConfirm().Title("Continue?")))
+	}
+	forms := []bobarista.Form{
+		bobarista.NewForm("a-b", "Dashed").WithGenerator(gen),
+		bobarista.NewForm("a_b", "Underscored").WithGenerator(gen).WithNavigationTargets("a-b"),
+		bobarista.NewForm("a b", "Spaced").WithGenerator(gen),
+	}
+
+	diagram, err := bobarista.NewNavigator(forms).Diagram(bobarista.DiagramMermaid, []string{"a-b", "a_b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobaristatest.AssertSnapshot(t, "diagram_collision_mermaid", diagram)
+}
@@ -0,0 +1,189 @@
+package bobarista
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// ThemeColor is a color with variants for light and dark terminal backgrounds.
+// If only one variant is given, it is used for both.
+type ThemeColor struct {
+	Light string `json:"light" yaml:"light" toml:"light"`
+	Dark  string `json:"dark" yaml:"dark" toml:"dark"`
+}
+
+// ThemeFile is the on-disk representation of a ColorScheme.
+// It can be written as TOML, JSON, or YAML.
+type ThemeFile struct {
+	Name      string     `json:"name" yaml:"name" toml:"name"`
+	Primary   ThemeColor `json:"primary" yaml:"primary" toml:"primary"`
+	Secondary ThemeColor `json:"secondary" yaml:"secondary" toml:"secondary"`
+	Tertiary  ThemeColor `json:"tertiary" yaml:"tertiary" toml:"tertiary"`
+	Success   ThemeColor `json:"success" yaml:"success" toml:"success"`
+	Error     ThemeColor `json:"error" yaml:"error" toml:"error"`
+	Warning   ThemeColor `json:"warning" yaml:"warning" toml:"warning"`
+	Info      ThemeColor `json:"info" yaml:"info" toml:"info"`
+}
+
+// themeExtensions maps supported theme file extensions to their format names.
+var themeExtensions = map[string]string{
+	".toml": "toml",
+	".json": "json",
+	".yaml": "yaml",
+	".yml":  "yaml",
+}
+
+// ParseColorScheme decodes a color scheme in the given format ("toml", "json", or "yaml").
+// All seven semantic colors are required.
+func ParseColorScheme(data []byte, format string) (ColorScheme, error) {
+	var file ThemeFile
+	var err error
+
+	switch format {
+	case "toml":
+		err = toml.Unmarshal(data, &file)
+	case "json":
+		err = json.Unmarshal(data, &file)
+	case "yaml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		return ColorScheme{}, fmt.Errorf("%w: %q", ErrUnknownThemeFormat, format)
+	}
+	if err != nil {
+		return ColorScheme{}, err
+	}
+
+	return file.ColorScheme()
+}
+
+// LoadColorScheme reads a color scheme from a TOML, JSON, or YAML file.
+// The format is determined by the file extension.
+func LoadColorScheme(path string) (ColorScheme, error) {
+	format, ok := themeExtensions[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return ColorScheme{}, fmt.Errorf("%w: %s", ErrUnknownThemeFormat, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ColorScheme{}, err
+	}
+
+	scheme, err := ParseColorScheme(data, format)
+	if err != nil {
+		return ColorScheme{}, fmt.Errorf("invalid theme file %s: %w", path, err)
+	}
+	return scheme, nil
+}
+
+// LoadColorSchemes registers every theme file found in dir.
+// Each scheme is registered under its file name without the extension.
+// A missing directory is not an error. Files that fail to load are reported
+// together, while the valid ones are still registered.
+func LoadColorSchemes(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	collector := NewErrorCollector()
+	var names []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || themeExtensions[ext] == "" {
+			continue
+		}
+
+		scheme, err := LoadColorScheme(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			collector.Add(err)
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if scheme.Name == "" {
+			scheme.Name = name
+		}
+		schemesMu.Lock()
+		colorSchemes[name] = scheme
+		schemesMu.Unlock()
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if collector.HasErrors() {
+		return names, collector
+	}
+	return names, nil
+}
+
+// DefaultThemesDir returns the directory searched for theme files by default,
+// ~/.config/bobarista/themes.
+func DefaultThemesDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config/bobarista/themes"), nil
+}
+
+// ColorScheme converts the theme file into a ColorScheme.
+// It returns an error naming the first semantic color that is missing.
+func (t ThemeFile) ColorScheme() (ColorScheme, error) {
+	colors := []struct {
+		name  string
+		color ThemeColor
+	}{
+		{"primary", t.Primary},
+		{"secondary", t.Secondary},
+		{"tertiary", t.Tertiary},
+		{"success", t.Success},
+		{"error", t.Error},
+		{"warning", t.Warning},
+		{"info", t.Info},
+	}
+
+	adaptive := make([]lipgloss.AdaptiveColor, len(colors))
+	for i, c := range colors {
+		if c.color.Light == "" && c.color.Dark == "" {
+			return ColorScheme{}, fmt.Errorf("%w: %s", ErrMissingThemeColor, c.name)
+		}
+		adaptive[i] = c.color.adaptive()
+	}
+
+	return ColorScheme{
+		Name:      t.Name,
+		Primary:   adaptive[0],
+		Secondary: adaptive[1],
+		Tertiary:  adaptive[2],
+		Success:   adaptive[3],
+		Error:     adaptive[4],
+		Warning:   adaptive[5],
+		Info:      adaptive[6],
+	}, nil
+}
+
+// adaptive converts the theme color into a lipgloss.AdaptiveColor,
+// using one variant for both backgrounds if the other is missing.
+func (c ThemeColor) adaptive() lipgloss.AdaptiveColor {
+	light, dark := c.Light, c.Dark
+	if light == "" {
+		light = dark
+	}
+	if dark == "" {
+		dark = light
+	}
+	return lipgloss.AdaptiveColor{Light: light, Dark: dark}
+}
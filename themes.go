@@ -1,9 +1,17 @@
 package bobarista
 
 import (
+	"os"
+	"sync"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
+// AutoColorScheme is the name of the scheme chosen from the terminal environment.
+// It resolves to "monochrome" when NO_COLOR is set, "dark" on dark backgrounds,
+// and "default" otherwise.
+const AutoColorScheme = "auto"
+
 // ColorScheme defines a complete color theme for the Bobarista form flow.
 // It provides colors for different UI elements and semantic meanings.
 type ColorScheme struct {
@@ -49,6 +57,9 @@ var (
 	gray     = lipgloss.AdaptiveColor{Light: "#808080", Dark: "#A0A0A0"}
 )
 
+// schemesMu guards colorSchemes, which theme files and RegisterColorScheme can change at any time.
+var schemesMu sync.RWMutex
+
 // colorSchemes contains all built-in color schemes available in Bobarista.
 var colorSchemes = map[string]ColorScheme{
 	"default": {
@@ -125,15 +136,43 @@ var colorSchemes = map[string]ColorScheme{
 
 // GetColorScheme retrieves a color scheme by name.
 // Returns the color scheme and true if found, or an empty scheme and false if not found.
+// The name "auto" resolves to a built-in scheme based on the terminal environment.
 func GetColorScheme(name string) (ColorScheme, bool) {
+	if name == AutoColorScheme {
+		return resolveAutoColorScheme(), true
+	}
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
 	scheme, exists := colorSchemes[name]
 	return scheme, exists
 }
 
+// resolveAutoColorScheme picks a built-in scheme for the current terminal.
+func resolveAutoColorScheme() ColorScheme {
+	name := "default"
+	switch {
+	case noColor():
+		name = "monochrome"
+	case lipgloss.HasDarkBackground():
+		name = "dark"
+	}
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	return colorSchemes[name]
+}
+
+// noColor reports whether the NO_COLOR convention asks for colorless output.
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
 // GetAvailableColorSchemes returns a list of all available color scheme names.
 // This is useful for providing users with theme selection options.
 func GetAvailableColorSchemes() []string {
-	schemes := make([]string, 0, len(colorSchemes))
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	schemes := make([]string, 0, len(colorSchemes)+1)
+	schemes = append(schemes, AutoColorScheme)
 	for name := range colorSchemes {
 		schemes = append(schemes, name)
 	}
@@ -144,6 +183,8 @@ func GetAvailableColorSchemes() []string {
 // This allows users to define and register custom themes.
 func RegisterColorScheme(name string, scheme ColorScheme) {
 	scheme.Name = name
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
 	colorSchemes[name] = scheme
 }
 
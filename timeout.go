@@ -0,0 +1,231 @@
+package bobarista
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// TimeoutAction selects what happens when a form or flow timeout expires.
+type TimeoutAction int
+
+const (
+	// TimeoutAbort ends the flow with ErrTimeout.
+	TimeoutAbort TimeoutAction = iota
+	// TimeoutSubmit submits the current form with the values its fields hold, usually
+	// their defaults. After a flow timeout every remaining form is submitted this way.
+	TimeoutSubmit
+	// TimeoutReset discards all values and returns to the first form.
+	TimeoutReset
+)
+
+// String returns the name of the timeout action.
+func (a TimeoutAction) String() string {
+	switch a {
+	case TimeoutAbort:
+		return "Abort"
+	case TimeoutSubmit:
+		return "Submit"
+	case TimeoutReset:
+		return "Reset"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(a))
+	}
+}
+
+// timeoutTickMsg checks the timeout deadlines and refreshes the countdown.
+type timeoutTickMsg struct {
+	run int
+	seq int
+}
+
+// hasTimeouts reports whether the flow or any of its forms has a timeout.
+func (f *Bobarista) hasTimeouts() bool {
+	if f.config.Timeout > 0 {
+		return true
+	}
+	for _, form := range f.forms {
+		if form.Timeout > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// armTimeouts starts the timeouts on the first key press after the flow starts or is reset,
+// so a flow waiting untouched for its next user does not time out.
+func (f *Bobarista) armTimeouts() tea.Cmd {
+	if f.timeoutsArmed || f.state != StateActive || !f.hasTimeouts() {
+		return nil
+	}
+	f.timeoutsArmed = true
+	f.debugLog("Arming timeouts")
+
+	now := time.Now()
+	f.flowDeadline = time.Time{}
+	if f.config.Timeout > 0 {
+		f.flowDeadline = now.Add(f.config.Timeout)
+	}
+	f.formDeadline = time.Time{}
+	if current := f.navigator.Current(); current != nil && current.Timeout > 0 {
+		f.formDeadline = now.Add(current.Timeout)
+	}
+
+	f.timeoutSeq++
+	return f.timeoutTick(now)
+}
+
+// startFormTimeout sets the deadline of a form that is entered while timeouts are armed.
+func (f *Bobarista) startFormTimeout(form *Form) {
+	f.formDeadline = time.Time{}
+	if f.timeoutsArmed && form.Timeout > 0 {
+		f.formDeadline = time.Now().Add(form.Timeout)
+	}
+}
+
+// restartFormTimeout pushes the current form's deadline forward on key activity,
+// so a form times out only after sitting idle. The flow deadline is never extended.
+func (f *Bobarista) restartFormTimeout() {
+	if current := f.navigator.Current(); current != nil {
+		f.startFormTimeout(current)
+	}
+}
+
+// nextDeadline returns the earliest active deadline.
+func (f *Bobarista) nextDeadline() (time.Time, bool) {
+	if !f.timeoutsArmed {
+		return time.Time{}, false
+	}
+	deadline := f.flowDeadline
+	if deadline.IsZero() || !f.formDeadline.IsZero() && f.formDeadline.Before(deadline) {
+		deadline = f.formDeadline
+	}
+	return deadline, !deadline.IsZero()
+}
+
+// timeoutExpired reports whether an armed deadline has passed.
+// Key presses arriving after a deadline run the timeout action instead of reaching the form.
+func (f *Bobarista) timeoutExpired() bool {
+	deadline, ok := f.nextDeadline()
+	return ok && f.state == StateActive && !time.Now().Before(deadline)
+}
+
+// timeoutTick schedules the next check, at the next deadline or in a second, whichever is sooner.
+func (f *Bobarista) timeoutTick(now time.Time) tea.Cmd {
+	interval := time.Second
+	if deadline, ok := f.nextDeadline(); ok && deadline.Sub(now) < interval {
+		interval = deadline.Sub(now)
+	}
+	run, seq := f.runID, f.timeoutSeq
+	if interval <= 0 {
+		return func() tea.Msg { return timeoutTickMsg{run: run, seq: seq} }
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return timeoutTickMsg{run: run, seq: seq}
+	})
+}
+
+// handleTimeoutTick runs the timeout action once a deadline has passed, and otherwise keeps ticking.
+func (f *Bobarista) handleTimeoutTick(msg timeoutTickMsg) tea.Cmd {
+	if msg.run != f.runID || msg.seq != f.timeoutSeq || !f.timeoutsArmed || f.state != StateActive {
+		return nil
+	}
+
+	now := time.Now()
+	flowExpired := !f.flowDeadline.IsZero() && !now.Before(f.flowDeadline)
+	formExpired := !f.formDeadline.IsZero() && !now.Before(f.formDeadline)
+	if !flowExpired && !formExpired {
+		return f.timeoutTick(now)
+	}
+	if f.loading || f.currentForm == nil {
+		run, seq := f.runID, f.timeoutSeq
+		return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+			return timeoutTickMsg{run: run, seq: seq}
+		})
+	}
+
+	current := f.navigator.Current()
+	scope := "form"
+	if flowExpired {
+		scope = "flow"
+	}
+	f.infoLog(fmt.Sprintf("%s timeout expired on form '%s', action %s", scope, current.ID, f.config.TimeoutAction))
+
+	if !f.timedOut {
+		f.timedOut = true
+		f.emit(Event{Type: EventTimedOut, FormID: current.ID})
+		if f.config.OnTimeout != nil {
+			f.debugLog("Calling OnTimeout hook")
+			if err := f.config.OnTimeout(f, f.formDataFor(current)); err != nil {
+				f.errorLog(fmt.Errorf("OnTimeout error for form '%s': %w", current.ID, err))
+				f.addError(current.ID, err)
+				return nil
+			}
+		}
+	}
+
+	switch f.config.TimeoutAction {
+	case TimeoutSubmit:
+		f.currentForm.State = huh.StateCompleted
+		_, cmd := f.handleFormCompletion()
+		f.timedOut = flowExpired
+		if f.state != StateActive {
+			return cmd
+		}
+		return tea.Batch(cmd, f.timeoutTick(time.Now()))
+	case TimeoutReset:
+		return f.resetFlow()
+	default:
+		f.addError(current.ID, fmt.Errorf("%w after %s", ErrTimeout, f.timeoutFor(flowExpired, current)))
+		f.abortFlow()
+		return f.quit()
+	}
+}
+
+// timeoutFor returns the duration of the timeout that expired.
+func (f *Bobarista) timeoutFor(flow bool, form *Form) time.Duration {
+	if flow {
+		return f.config.Timeout
+	}
+	return form.Timeout
+}
+
+// resetFlow discards the answers and restarts the flow at the first form with the
+// values it started with. Timeouts are armed again on the next key press.
+func (f *Bobarista) resetFlow() tea.Cmd {
+	f.infoLog("Resetting flow to the first form")
+	f.timeoutsArmed = false
+	f.timedOut = false
+	f.flowDeadline = time.Time{}
+	f.formDeadline = time.Time{}
+
+	initial := f.initial.Copy()
+	f.globalData = &FormData{ID: "global", Values: &initial}
+	f.formValues = make(map[string]FormValues)
+	f.ownership.reset()
+	f.derivedInputs = nil
+	f.skipReasons = nil
+	f.currentForm = nil
+	f.loading = false
+	f.confirmingQuit = false
+	f.navigator.Reset()
+
+	return f.start()
+}
+
+// renderCountdown renders the time left until the earliest timeout, or "" if none is running.
+func (r *Renderer) renderCountdown(cupSleeve *Bobarista) string {
+	deadline, ok := cupSleeve.nextDeadline()
+	if !ok {
+		return ""
+	}
+	remaining := time.Until(deadline).Round(time.Second)
+	if remaining < 0 {
+		remaining = 0
+	}
+	minutes := int(remaining / time.Minute)
+	seconds := int(remaining % time.Minute / time.Second)
+	return r.text(MsgTimeRemaining, fmt.Sprintf("%d:%02d", minutes, seconds))
+}